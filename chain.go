@@ -0,0 +1,336 @@
+package inflate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ChainPolicy defines how a chain combines the values of its providers
+type ChainPolicy int
+
+const (
+	// ChainFirst uses the value of the first provider that returns a non-nil value
+	ChainFirst ChainPolicy = iota
+	// ChainMerge merges the maps and concatenates the slices returned by all
+	// providers. The keys of the preceding providers take precedence.
+	ChainMerge
+)
+
+// Origin describes which providers supplied the value of a field
+type Origin struct {
+	Field     string
+	Name      string
+	Path      []string
+	Providers []ValueProvider
+}
+
+var _ ContextValueProvider = &ChainProvider{}
+
+var _ ValueProvider = &ChainProvider{}
+
+// ChainProvider represents a parameter provider that fetches values from
+// a list of providers ordered by their precedence
+type ChainProvider struct {
+	Providers []ValueProvider
+	Policy    ChainPolicy
+	mu        sync.Mutex
+	origins   *origins
+}
+
+// Value returns a primitive value
+func (p *ChainProvider) Value(ctx *Context) (interface{}, error) {
+	return p.ValueContext(context.Background(), ctx)
+}
+
+// ValueContext returns a primitive value. The context is passed to the
+// providers that implement ContextValueProvider.
+func (p *ChainProvider) ValueContext(parent context.Context, ctx *Context) (interface{}, error) {
+	var (
+		values   []interface{}
+		sources  []ValueProvider
+		recorder = p.recorder(parent)
+	)
+
+	for _, provider := range p.Providers {
+		if err := parent.Err(); err != nil {
+			return nil, err
+		}
+
+		value, err := valueContext(parent, provider, p.context(ctx, provider))
+		if err != nil {
+			return nil, err
+		}
+
+		if value == nil {
+			continue
+		}
+
+		values = append(values, value)
+		sources = append(sources, provider)
+
+		if p.Policy == ChainFirst {
+			break
+		}
+
+		if kind := ctx.Type.Kind(); kind != reflect.Map && kind != reflect.Slice && kind != reflect.Array {
+			break
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	recorder.record(ctx, sources)
+
+	switch ctx.Type.Kind() {
+	case reflect.Map:
+		return p.mapOf(values), nil
+	case reflect.Array, reflect.Slice:
+		return p.arrayOf(values), nil
+	default:
+		return values[0], nil
+	}
+}
+
+// Origins returns the providers that supplied the value of each field. The
+// decoders report the fields of their last decode.
+func (p *ChainProvider) Origins() []*Origin {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.origins == nil {
+		return nil
+	}
+
+	return p.origins.list()
+}
+
+// recorder returns the origins of the decode that fetches the value or the
+// provider's own ones if it is called directly
+func (p *ChainProvider) recorder(parent context.Context) *origins {
+	if report, ok := parent.Value(originsKey{}).(*originReport); ok {
+		return report.of(p)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.origins == nil {
+		p.origins = &origins{}
+	}
+
+	return p.origins
+}
+
+// report replaces the origins with the ones of a finished decode
+func (p *ChainProvider) report(report *origins) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.origins = report
+}
+
+func (p *ChainProvider) mapOf(values []interface{}) interface{} {
+	result := make(map[string]interface{})
+
+	for index := len(values) - 1; index >= 0; index-- {
+		value := elem(reflect.ValueOf(values[index]))
+
+		if value.Kind() != reflect.Map {
+			return values[0]
+		}
+
+		iter := value.MapRange()
+
+		for iter.Next() {
+			key := fmt.Sprintf("%v", iter.Key().Interface())
+			result[key] = iter.Value().Interface()
+		}
+	}
+
+	return result
+}
+
+func (p *ChainProvider) arrayOf(values []interface{}) interface{} {
+	result := []interface{}{}
+
+	for _, item := range values {
+		value := elem(reflect.ValueOf(item))
+
+		switch value.Kind() {
+		case reflect.Array, reflect.Slice:
+			if value.Type().Elem().Kind() == reflect.Uint8 {
+				return values[0]
+			}
+
+			for index := 0; index < value.Len(); index++ {
+				result = append(result, value.Index(index).Interface())
+			}
+		default:
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func (p *ChainProvider) context(ctx *Context, provider ValueProvider) *Context {
+	// the providers might add their default options to the tag
+	tag := *ctx.Tag
+	tag.Options = append([]string{}, ctx.Tag.Options...)
+
+	// the providers join the namespaced keys in their own way
+	if joiner, ok := provider.(KeyJoiner); ok && len(ctx.TagPath) > 1 {
+		tag.Name = joiner.JoinKey(ctx.TagPath...)
+	}
+
+	next := *ctx
+	next.Tag = &tag
+
+	return &next
+}
+
+type originsKey struct{}
+
+// originReport collects the origins of the chains used by a single decode
+// however they are wrapped
+type originReport struct {
+	mu     sync.Mutex
+	chains map[*ChainProvider]*origins
+}
+
+// withOriginReport returns a context that collects the origins of a decode
+// unless the parent already does
+func withOriginReport(parent context.Context) (context.Context, *originReport) {
+	if _, ok := parent.Value(originsKey{}).(*originReport); ok {
+		return parent, nil
+	}
+
+	report := &originReport{
+		chains: make(map[*ChainProvider]*origins),
+	}
+
+	return context.WithValue(parent, originsKey{}, report), report
+}
+
+func (r *originReport) of(chain *ChainProvider) *origins {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.chains[chain]; !ok {
+		r.chains[chain] = &origins{}
+	}
+
+	return r.chains[chain]
+}
+
+// publish replaces the origins of the chains with the ones of the decode
+func (r *originReport) publish() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for chain, origins := range r.chains {
+		chain.report(origins)
+	}
+}
+
+// origins collects the origins of the fields of a single decode
+type origins struct {
+	mu    sync.Mutex
+	items []*Origin
+}
+
+func (o *origins) record(ctx *Context, sources []ValueProvider) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, origin := range o.items {
+		if origin.Field == ctx.Field && origin.Name == ctx.Tag.Name && reflect.DeepEqual(origin.Path, ctx.Path) {
+			origin.Providers = sources
+			return
+		}
+	}
+
+	o.items = append(o.items, &Origin{
+		Field:     ctx.Field,
+		Name:      ctx.Tag.Name,
+		Path:      ctx.Path,
+		Providers: sources,
+	})
+}
+
+func (o *origins) list() []*Origin {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]*Origin{}, o.items...)
+}
+
+var _ ContextValueProvider = &TagProvider{}
+
+var _ ValueProvider = &TagProvider{}
+
+var _ KeyJoiner = &TagProvider{}
+
+// TagProvider represents a parameter provider that reads the field's tag with
+// the given name before it fetches the value from the underlying provider. It
+// allows providers with different tags to be composed in a chain.
+type TagProvider struct {
+	TagName  string
+	Provider ValueProvider
+}
+
+// Value returns a primitive value
+func (p *TagProvider) Value(ctx *Context) (interface{}, error) {
+	return p.ValueContext(context.Background(), ctx)
+}
+
+// ValueContext returns a primitive value. The context is passed to the
+// underlying provider if it implements ContextValueProvider.
+func (p *TagProvider) ValueContext(parent context.Context, ctx *Context) (interface{}, error) {
+	tag := ParseTag(p.TagName, ctx.StructField.Tag.Get(p.TagName))
+
+	if tag.Name == "-" {
+		return nil, nil
+	}
+
+	if tag.Key != "default" && tag.Name == "" {
+		tag.Name = ctx.StructField.Name
+	}
+
+	next := *ctx
+	next.Tag = tag
+
+	// the fields of the prefixed structs are read from namespaced keys
+	if tag.Key != "default" && len(ctx.TagPath) > 1 {
+		next.TagPath = append(append([]string{}, ctx.TagPath[:len(ctx.TagPath)-1]...), tag.Name)
+		tag.Name = p.JoinKey(next.TagPath...)
+	}
+
+	return valueContext(parent, p.Provider, &next)
+}
+
+// JoinKey joins the names in the way of the underlying provider
+func (p *TagProvider) JoinKey(names ...string) string {
+	if joiner, ok := p.Provider.(KeyJoiner); ok {
+		return joiner.JoinKey(names...)
+	}
+
+	return strings.Join(names, ".")
+}
+
+func valueContext(parent context.Context, provider ValueProvider, ctx *Context) (interface{}, error) {
+	if provider, ok := provider.(ContextValueProvider); ok {
+		return provider.ValueContext(parent, ctx)
+	}
+
+	return provider.Value(ctx)
+}
@@ -0,0 +1,50 @@
+package inflate_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/phogolabs/inflate"
+)
+
+func ExampleChainProvider() {
+	type Config struct {
+		Addr     string `env:"EXAMPLE_ADDR" default:":8080"`
+		LogLevel string `env:"EXAMPLE_LOG_LEVEL" default:"info"`
+	}
+
+	os.Setenv("EXAMPLE_LOG_LEVEL", "debug")
+	defer os.Unsetenv("EXAMPLE_LOG_LEVEL")
+
+	provider := &inflate.ChainProvider{
+		Providers: []inflate.ValueProvider{
+			&inflate.TagProvider{TagName: "env", Provider: &inflate.EnvProvider{}},
+			&inflate.TagProvider{TagName: "default", Provider: &inflate.DefaultProvider{}},
+		},
+	}
+
+	decoder := &inflate.Decoder{
+		TagName: "env",
+		Converter: &inflate.Converter{
+			TagName: "env",
+		},
+		Provider: provider,
+	}
+
+	config := &Config{}
+
+	if err := decoder.Decode(config); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%+v\n", config)
+
+	for _, origin := range provider.Origins() {
+		fmt.Printf("%v: %T\n", origin.Field, origin.Providers[0].(*inflate.TagProvider).Provider)
+	}
+
+	// Output:
+	// &{Addr::8080 LogLevel:debug}
+	// Addr: *inflate.DefaultProvider
+	// LogLevel: *inflate.EnvProvider
+}
@@ -0,0 +1,348 @@
+package inflate_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/phogolabs/inflate"
+	"github.com/phogolabs/inflate/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChainProvider", func() {
+	var (
+		provider *inflate.ChainProvider
+		primary  *fake.ValueProvider
+		fallback *fake.ValueProvider
+		ctx      *inflate.Context
+	)
+
+	BeforeEach(func() {
+		ctx = &inflate.Context{
+			Field: "ID",
+			Type:  reflect.TypeOf(""),
+			Tag: &inflate.Tag{
+				Key:  "fake",
+				Name: "id",
+			},
+		}
+
+		primary = &fake.ValueProvider{}
+		fallback = &fake.ValueProvider{}
+
+		provider = &inflate.ChainProvider{
+			Providers: []inflate.ValueProvider{primary, fallback},
+		}
+	})
+
+	It("returns the value of the first provider", func() {
+		primary.ValueReturns("1", nil)
+		fallback.ValueReturns("2", nil)
+
+		value, err := provider.Value(ctx)
+		Expect(err).To(BeNil())
+		Expect(value).To(Equal("1"))
+		Expect(fallback.ValueCallCount()).To(Equal(0))
+
+		origins := provider.Origins()
+		Expect(origins).To(HaveLen(1))
+		Expect(origins[0].Field).To(Equal("ID"))
+		Expect(origins[0].Name).To(Equal("id"))
+		Expect(origins[0].Providers).To(ConsistOf(primary))
+	})
+
+	It("does not share the tag between the providers", func() {
+		primary.ValueStub = func(ctx *inflate.Context) (interface{}, error) {
+			ctx.Tag.AddOption("form")
+			return nil, nil
+		}
+
+		fallback.ValueReturns("2", nil)
+
+		value, err := provider.Value(ctx)
+		Expect(err).To(BeNil())
+		Expect(value).To(Equal("2"))
+		Expect(ctx.Tag.Options).To(BeEmpty())
+		Expect(fallback.ValueArgsForCall(0).Tag.Options).To(BeEmpty())
+	})
+
+	Context("when the first provider does not have a value", func() {
+		It("returns the value of the next provider", func() {
+			fallback.ValueReturns("2", nil)
+
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("2"))
+			Expect(provider.Origins()[0].Providers).To(ConsistOf(fallback))
+		})
+	})
+
+	Context("when none of the providers has a value", func() {
+		It("returns a nil value successfully", func() {
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(BeNil())
+			Expect(provider.Origins()).To(BeEmpty())
+		})
+	})
+
+	Context("when the context is canceled", func() {
+		It("returns an error", func() {
+			parent, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			value, err := provider.ValueContext(parent, ctx)
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(value).To(BeNil())
+			Expect(primary.ValueCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the provider fails", func() {
+		It("returns an error", func() {
+			primary.ValueReturns(nil, fmt.Errorf("oh no"))
+
+			value, err := provider.Value(ctx)
+			Expect(err).To(MatchError("oh no"))
+			Expect(value).To(BeNil())
+		})
+	})
+
+	Context("when the policy is merge", func() {
+		BeforeEach(func() {
+			provider.Policy = inflate.ChainMerge
+		})
+
+		Context("when the value is map type", func() {
+			BeforeEach(func() {
+				ctx.Type = reflect.TypeOf(map[string]interface{}{})
+			})
+
+			It("merges the values", func() {
+				primary.ValueReturns(map[string]interface{}{"name": "Jack"}, nil)
+				fallback.ValueReturns(map[string]interface{}{"name": "Peter", "role": "admin"}, nil)
+
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(HaveKeyWithValue("name", "Jack"))
+				Expect(value).To(HaveKeyWithValue("role", "admin"))
+				Expect(provider.Origins()[0].Providers).To(ConsistOf(primary, fallback))
+			})
+		})
+
+		Context("when the value is array type", func() {
+			BeforeEach(func() {
+				ctx.Type = reflect.TypeOf([]interface{}{})
+			})
+
+			It("concatenates the values", func() {
+				primary.ValueReturns([]interface{}{"1", "2"}, nil)
+				fallback.ValueReturns("3", nil)
+
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(Equal([]interface{}{"1", "2", "3"}))
+			})
+		})
+
+		Context("when the value is primitive type", func() {
+			It("returns the value of the first provider", func() {
+				primary.ValueReturns("1", nil)
+				fallback.ValueReturns("2", nil)
+
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(Equal("1"))
+				Expect(fallback.ValueCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the provider is used by a decoder", func() {
+		type Account struct {
+			ID   string `fake:"id"`
+			Name string `fake:"name"`
+		}
+
+		var decoder *inflate.Decoder
+
+		BeforeEach(func() {
+			decoder = &inflate.Decoder{
+				TagName: "fake",
+				Converter: &inflate.Converter{
+					TagName: "fake",
+				},
+				Provider: provider,
+			}
+		})
+
+		It("reports the origins of the last decode", func() {
+			primary.ValueReturns("1", nil)
+			Expect(decoder.Decode(&Account{})).To(Succeed())
+			Expect(provider.Origins()).To(HaveLen(2))
+
+			primary.ValueStub = func(ctx *inflate.Context) (interface{}, error) {
+				if ctx.Tag.Name == "id" {
+					return "2", nil
+				}
+
+				return nil, nil
+			}
+
+			Expect(decoder.Decode(&Account{})).To(Succeed())
+
+			origins := provider.Origins()
+			Expect(origins).To(HaveLen(1))
+			Expect(origins[0].Field).To(Equal("ID"))
+			Expect(origins[0].Providers).To(ConsistOf(primary))
+		})
+
+		Context("when the chain is wrapped", func() {
+			BeforeEach(func() {
+				decoder.Provider = &inflate.TagProvider{TagName: "fake", Provider: provider}
+			})
+
+			It("reports the origins of the last decode", func() {
+				primary.ValueReturns("1", nil)
+				Expect(decoder.Decode(&Account{})).To(Succeed())
+				Expect(provider.Origins()).To(HaveLen(2))
+
+				primary.ValueReturns(nil, nil)
+				Expect(decoder.Decode(&Account{})).To(Succeed())
+				Expect(provider.Origins()).To(BeEmpty())
+			})
+		})
+
+		It("decodes the values concurrently", func() {
+			primary.ValueReturns("1", nil)
+
+			var group sync.WaitGroup
+
+			for index := 0; index < 8; index++ {
+				group.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer group.Done()
+
+					Expect(decoder.Decode(&Account{})).To(Succeed())
+				}()
+			}
+
+			group.Wait()
+			Expect(provider.Origins()).To(HaveLen(2))
+		})
+	})
+})
+
+var _ = Describe("TagProvider", func() {
+	type Config struct {
+		Addr string `env:"INFLATE_ADDR" default:":8080"`
+		Port int
+		Skip string `env:"-"`
+	}
+
+	var (
+		provider *inflate.TagProvider
+		fallback *fake.ValueProvider
+		field    reflect.StructField
+	)
+
+	BeforeEach(func() {
+		fallback = &fake.ValueProvider{}
+		fallback.ValueReturns("value", nil)
+
+		provider = &inflate.TagProvider{
+			TagName:  "env",
+			Provider: fallback,
+		}
+
+		field, _ = reflect.TypeOf(Config{}).FieldByName("Addr")
+	})
+
+	It("provides the tag with the given name", func() {
+		ctx := &inflate.Context{
+			Tag:         &inflate.Tag{Key: "default", Name: ":8080"},
+			StructField: field,
+		}
+
+		value, err := provider.Value(ctx)
+		Expect(err).To(BeNil())
+		Expect(value).To(Equal("value"))
+
+		tag := fallback.ValueArgsForCall(0).Tag
+		Expect(tag.Key).To(Equal("env"))
+		Expect(tag.Name).To(Equal("INFLATE_ADDR"))
+	})
+
+	Context("when the tag is not present", func() {
+		BeforeEach(func() {
+			field, _ = reflect.TypeOf(Config{}).FieldByName("Port")
+		})
+
+		It("uses the field name", func() {
+			_, err := provider.Value(&inflate.Context{StructField: field})
+			Expect(err).To(BeNil())
+			Expect(fallback.ValueArgsForCall(0).Tag.Name).To(Equal("Port"))
+		})
+	})
+
+	Context("when the struct field has a prefix", func() {
+		type Database struct {
+			Host string `env:"host" default:"localhost"`
+			Port int    `env:"port" default:"5432"`
+		}
+
+		type Settings struct {
+			Database Database `env:"db,prefix"`
+		}
+
+		BeforeEach(func() {
+			Expect(os.Setenv("DB_HOST", "remote")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "DB_HOST")
+		})
+
+		It("reads the namespaced keys of the underlying provider", func() {
+			decoder := &inflate.Decoder{
+				TagName: "env",
+				Converter: &inflate.Converter{
+					TagName: "env",
+				},
+				Provider: &inflate.ChainProvider{
+					Providers: []inflate.ValueProvider{
+						&inflate.TagProvider{TagName: "env", Provider: &inflate.EnvProvider{}},
+						&inflate.TagProvider{TagName: "default", Provider: &inflate.DefaultProvider{}},
+					},
+				},
+			}
+
+			settings := &Settings{}
+			Expect(decoder.Decode(settings)).To(Succeed())
+			Expect(settings.Database.Host).To(Equal("remote"))
+			Expect(settings.Database.Port).To(Equal(5432))
+		})
+
+		It("joins the keys in the way of the underlying provider", func() {
+			provider.Provider = &inflate.EnvProvider{}
+			Expect(provider.JoinKey("db", "host")).To(Equal("DB_HOST"))
+		})
+	})
+
+	Context("when the field is skipped", func() {
+		BeforeEach(func() {
+			field, _ = reflect.TypeOf(Config{}).FieldByName("Skip")
+		})
+
+		It("returns a nil value successfully", func() {
+			value, err := provider.Value(&inflate.Context{StructField: field})
+			Expect(err).To(BeNil())
+			Expect(value).To(BeNil())
+			Expect(fallback.ValueCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package inflate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//go:generate counterfeiter -fake-name ValueChecker -o ./fake/value_checker.go . ValueChecker
+
+// ValueChecker checks whether the tag options of a field are supported for
+// the field's type without fetching its value
+type ValueChecker interface {
+	Check(ctx *Context) error
+}
+
+var checkers = map[string]ValueChecker{
+	"path":   &PathProvider{},
+	"query":  &QueryProvider{},
+	"form":   &QueryProvider{},
+	"header": &HeaderProvider{},
+	"cookie": &CookieProvider{},
+}
+
+type checkKey struct {
+	TagName string
+	Checker reflect.Type
+	Type    reflect.Type
+}
+
+var checks sync.Map
+
+// CheckError contains all problems found by Check
+type CheckError struct {
+	Errors []error
+}
+
+// Error returns the error message
+func (e *CheckError) Error() string {
+	messages := make([]string, len(e.Errors))
+
+	for index, err := range e.Errors {
+		messages[index] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors
+func (e *CheckError) Unwrap() []error {
+	return e.Errors
+}
+
+// Check checks the options of the target's fields tagged with given tag
+// (path, query, form, header or cookie) against the styles supported by its
+// provider. It reports all problems at once.
+func Check(target interface{}, tag string) error {
+	checker, ok := checkers[tag]
+
+	if !ok {
+		return fmt.Errorf("check: tag: '%v' not supported", tag)
+	}
+
+	kind := reflect.TypeOf(target)
+
+	for kind != nil && kind.Kind() == reflect.Ptr {
+		kind = kind.Elem()
+	}
+
+	if kind == nil || kind.Kind() != reflect.Struct {
+		return fmt.Errorf("check: type: %v is not a struct", kind)
+	}
+
+	return checkType(tag, kind, checker)
+}
+
+// checkType checks the type once and caches the result
+func checkType(tag string, kind reflect.Type, checker ValueChecker) error {
+	for kind.Kind() == reflect.Ptr {
+		kind = kind.Elem()
+	}
+
+	if kind.Kind() != reflect.Struct {
+		return nil
+	}
+
+	key := checkKey{
+		TagName: tag,
+		Checker: reflect.TypeOf(checker),
+		Type:    kind,
+	}
+
+	if err, ok := checks.Load(key); ok {
+		if err == nil {
+			return nil
+		}
+
+		return err.(error)
+	}
+
+	errs := []error{}
+	checkStruct(StructOf(tag, reflect.New(kind).Elem()), checker, &errs, &Context{})
+
+	var err error
+
+	if len(errs) > 0 {
+		err = &CheckError{Errors: errs}
+	}
+
+	checks.Store(key, err)
+	return err
+}
+
+func checkStruct(ch *Struct, checker ValueChecker, errs *[]error, owner *Context) {
+	for _, field := range ch.Fields() {
+		var (
+			target = refer(field.Value)
+			ctx    = owner.next(ch.Value, field)
+		)
+
+		if field.Tag.Name == "~" || prefixed(ctx, target) {
+			if kind(target) == reflect.Struct {
+				checkStruct(field.inline(ch.TagName, target), checker, errs, ctx)
+			}
+
+			continue
+		}
+
+		ctx.Type = target.Type()
+
+		if setter, ok := optionalOf(target); ok {
+			ctx.Type = setter.optionalType()
+		}
+
+		if err := checker.Check(ctx); err != nil {
+			*errs = append(*errs, err)
+		}
+	}
+}
+
+// shape returns how a provider reads a value of given type
+func shape(t reflect.Type) reflect.Kind {
+	if convertable(t) {
+		return reflect.String
+	}
+
+	switch t.Kind() {
+	case reflect.Map, reflect.Struct:
+		return reflect.Map
+	case reflect.Array, reflect.Slice:
+		return reflect.Slice
+	default:
+		return reflect.String
+	}
+}
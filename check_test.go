@@ -0,0 +1,89 @@
+package inflate_test
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Check", func() {
+	type Filter struct {
+		Name string `query:"name"`
+	}
+
+	type Valid struct {
+		ID     string            `path:"id,label"`
+		Tags   []string          `query:"tags,pipe-delimited"`
+		Filter Filter            `query:"filter,deep-object"`
+		Labels map[string]string `header:"X-Labels,simple,explode"`
+		Token  string            `cookie:"token"`
+	}
+
+	It("checks the target successfully", func() {
+		for _, tag := range []string{"path", "query", "header", "cookie"} {
+			Expect(inflate.Check(&Valid{}, tag)).To(Succeed())
+		}
+	})
+
+	Context("when the options are not supported", func() {
+		type Session struct {
+			Token string `query:"token,space-delimited"`
+		}
+
+		type Invalid struct {
+			ID      string   `path:"id,form"`
+			Tags    []string `query:"tags,deep-object"`
+			Filter  Filter   `query:"filter,deep-object,explode"`
+			Session *Session `query:"~"`
+			Trace   string   `header:"X-Trace,form"`
+			Roles   []string `cookie:"roles,form,explode"`
+		}
+
+		It("returns all errors of the query fields", func() {
+			err := inflate.Check(&Invalid{}, "query")
+			Expect(err).To(MatchError("query: field: 'tags' option: [deep-object] not supported\n" +
+				"query: field: 'filter' option: [explode] not supported\n" +
+				"query: field: 'token' option: [space-delimited] not supported"))
+
+			checkErr := &inflate.CheckError{}
+			Expect(errors.As(err, &checkErr)).To(BeTrue())
+			Expect(checkErr.Errors).To(HaveLen(3))
+		})
+
+		It("returns the errors of the path fields", func() {
+			Expect(inflate.Check(&Invalid{}, "path")).To(MatchError("path: field: id option: [simple label matrix] not provided"))
+		})
+
+		It("returns the errors of the header fields", func() {
+			Expect(inflate.Check(&Invalid{}, "header")).To(MatchError("header: field 'X-Trace' option: [simple] not provided"))
+		})
+
+		It("returns the errors of the cookie fields", func() {
+			Expect(inflate.Check(&Invalid{}, "cookie")).To(MatchError("cookie: field: 'roles' option: [explode] not supported"))
+		})
+
+		Context("when the target is decoded", func() {
+			It("returns the errors before the values are fetched", func() {
+				decoder := inflate.NewQueryDecoder(url.Values{})
+				Expect(decoder.Decode(&Invalid{})).To(MatchError(ContainSubstring("query: field: 'tags' option: [deep-object] not supported")))
+			})
+		})
+	})
+
+	Context("when the tag is not supported", func() {
+		It("returns an error", func() {
+			Expect(inflate.Check(&Valid{}, "env")).To(MatchError("check: tag: 'env' not supported"))
+		})
+	})
+
+	Context("when the target is not a struct", func() {
+		It("returns an error", func() {
+			value := 5
+			Expect(inflate.Check(&value, "query")).To(MatchError("check: type: int is not a struct"))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+package inflate_test
+
+import (
+	"testing"
+
+	"github.com/jinzhu/copier"
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type CloneAddress struct {
+	City string `field:"city"`
+}
+
+type CloneUser struct {
+	Name      string            `field:"name"`
+	Tags      []string          `field:"tags"`
+	Labels    map[string]string `field:"labels"`
+	Address   *CloneAddress     `field:"address"`
+	Addresses []*CloneAddress   `field:"addresses"`
+	Friend    *CloneUser        `field:"friend"`
+	Data      interface{}       `field:"data"`
+}
+
+type CloneAccount struct {
+	Username  string            `field:"name"`
+	Labels    map[string]string `field:"labels"`
+	Addresses []*CloneAddress   `field:"addresses"`
+}
+
+func cloneUser() *CloneUser {
+	return &CloneUser{
+		Name:      "Jack",
+		Tags:      []string{"admin"},
+		Labels:    map[string]string{"env": "prod"},
+		Address:   &CloneAddress{City: "London"},
+		Addresses: []*CloneAddress{{City: "Sofia"}},
+		Data:      map[string]interface{}{"key": []int{1}},
+	}
+}
+
+var _ = Describe("Clone", func() {
+	It("copies the value deeply", func() {
+		var (
+			source = cloneUser()
+			target = &CloneUser{}
+		)
+
+		Expect(inflate.Clone(target, source)).To(Succeed())
+		Expect(target).To(Equal(source))
+
+		target.Tags[0] = "guest"
+		target.Labels["env"] = "dev"
+		target.Address.City = "Paris"
+		target.Addresses[0].City = "Berlin"
+		target.Data.(map[string]interface{})["key"].([]int)[0] = 2
+
+		Expect(source).To(Equal(cloneUser()))
+	})
+
+	It("copies the values with the same tag name deeply", func() {
+		var (
+			source = cloneUser()
+			target = &CloneAccount{}
+		)
+
+		Expect(inflate.Clone(target, source)).To(Succeed())
+		Expect(target.Username).To(Equal("Jack"))
+		Expect(target.Labels).To(Equal(source.Labels))
+		Expect(target.Addresses).To(Equal(source.Addresses))
+
+		target.Labels["env"] = "dev"
+		target.Addresses[0].City = "Berlin"
+
+		Expect(source).To(Equal(cloneUser()))
+	})
+
+	Context("when the value has a cycle", func() {
+		It("copies the value successfully", func() {
+			var (
+				source = cloneUser()
+				target = &CloneUser{}
+			)
+
+			source.Friend = source
+
+			Expect(inflate.Clone(target, source)).To(Succeed())
+			Expect(target.Friend).To(BeIdenticalTo(target))
+			Expect(target.Friend.Name).To(Equal("Jack"))
+		})
+
+		It("copies the cycle through another value successfully", func() {
+			var (
+				source = cloneUser()
+				friend = cloneUser()
+				target = &CloneUser{}
+			)
+
+			friend.Name = "Peter"
+			friend.Friend = source
+			source.Friend = friend
+
+			Expect(inflate.Clone(target, source)).To(Succeed())
+			Expect(target.Friend).NotTo(BeIdenticalTo(friend))
+			Expect(target.Friend.Name).To(Equal("Peter"))
+			Expect(target.Friend.Friend).To(BeIdenticalTo(target))
+		})
+	})
+
+	Context("when the converter does not copy deeply", func() {
+		It("shares the values", func() {
+			var (
+				source = cloneUser()
+				target = &CloneUser{}
+			)
+
+			Expect(inflate.Set(target, source)).To(Succeed())
+
+			target.Labels["env"] = "dev"
+			Expect(source.Labels).To(HaveKeyWithValue("env", "dev"))
+		})
+	})
+})
+
+func BenchmarkClone(b *testing.B) {
+	source := cloneUser()
+
+	for index := 0; index < b.N; index++ {
+		target := &CloneUser{}
+
+		if err := inflate.Clone(target, source); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCloneCopier(b *testing.B) {
+	var (
+		source = cloneUser()
+		option = copier.Option{DeepCopy: true}
+	)
+
+	for index := 0; index < b.N; index++ {
+		target := &CloneUser{}
+
+		if err := copier.CopyWithOption(target, source, option); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -6,13 +6,24 @@ import (
 	"encoding"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 )
 
-// Struct works with structs
+const (
+	// OptionIndex is the index opt
+	OptionIndex = "index"
+	// OptionString is the string opt
+	OptionString = "string"
+)
+
+// Struct works with structs. The tag name can be a comma separated list of
+// tag keys (e.g. field,json) that are looked up in order.
 type Struct struct {
 	TagName string
 	Value   reflect.Value
+	visible map[string]bool
 }
 
 // StructOf returns the struct
@@ -23,42 +34,301 @@ func StructOf(tagName string, value reflect.Value) *Struct {
 	}
 }
 
-// Fields returns the struct fields
+// Fields returns the struct fields. The fields of the untagged embedded
+// structs are promoted like encoding/json does. A nil embedded pointer is
+// returned as an inlined (~) field.
 func (s *Struct) Fields() []*Field {
-	fields := []*Field{}
+	var (
+		fields = []*Field{}
+		nils   = make(map[string]bool)
+	)
 
-	for index := 0; index < s.Value.Type().NumField(); index++ {
-		var (
-			field = s.Value.Type().Field(index)
-			value = s.Value.Field(index)
-		)
+	infos := fieldsOf(s.TagName, s.Value.Type())
 
-		if field.PkgPath != "" {
+	for _, info := range infos {
+		// the fields of a nil embedded pointer hidden by the enclosing struct
+		if s.visible != nil && !s.visible[info.key] && !strings.HasPrefix(info.key, "~") {
 			continue
 		}
 
-		tag := ParseTag(s.TagName, field.Tag.Get(s.TagName))
+		value, embed, ok := fieldByIndex(s.Value, info.index)
+
+		if !ok {
+			if !nils[embed.Name] {
+				nils[embed.Name] = true
+
+				embed.Tag = &Tag{Key: s.TagName, Name: "~"}
+				embed.tagName = s.TagName
+				embed.visible = make(map[string]bool)
+
+				for _, item := range infos {
+					if len(item.index) > len(embed.index) && reflect.DeepEqual(item.index[:len(embed.index)], embed.index) {
+						embed.visible[item.key] = true
+					}
+				}
+
+				fields = append(fields, embed)
+			}
 
-		if tag == nil || tag.Name == "-" {
 			continue
 		}
 
+		tag := ParseTag(info.tagKey, info.tag)
+
 		if tag.Key != "default" {
 			if tag.Name == "" {
-				tag.Name = field.Name
+				tag.Name = info.name
 			}
 		}
 
 		fields = append(fields, &Field{
-			Tag:   tag,
-			Name:  field.Name,
-			Value: value,
+			Tag:     tag,
+			Name:    info.name,
+			Value:   value,
+			index:   info.index,
+			tagName: s.TagName,
 		})
 	}
 
 	return fields
 }
 
+type fieldInfo struct {
+	name   string
+	key    string
+	tagKey string
+	tag    string
+	tagged bool
+	index  []int
+}
+
+type fieldKey struct {
+	TagName string
+	Type    reflect.Type
+}
+
+var fieldCache sync.Map
+
+// fieldsOf returns the fields of the type including the promoted fields of
+// the untagged embedded structs. The conflicting names are resolved like
+// encoding/json does.
+func fieldsOf(tagName string, t reflect.Type) []fieldInfo {
+	key := fieldKey{TagName: tagName, Type: t}
+
+	if fields, ok := fieldCache.Load(key); ok {
+		return fields.([]fieldInfo)
+	}
+
+	var (
+		candidates = []fieldInfo{}
+		visited    = map[reflect.Type]bool{t: true}
+	)
+
+	collectFields(tagName, t, nil, visited, &candidates)
+
+	names := make(map[string][]fieldInfo)
+
+	for _, info := range candidates {
+		names[info.key] = append(names[info.key], info)
+	}
+
+	fields := []fieldInfo{}
+
+	for _, info := range candidates {
+		if dominant, ok := dominantField(names[info.key]); ok && reflect.DeepEqual(dominant.index, info.index) {
+			fields = append(fields, info)
+		}
+	}
+
+	fieldCache.Store(key, fields)
+	return fields
+}
+
+func collectFields(tagName string, t reflect.Type, index []int, visited map[reflect.Type]bool, fields *[]fieldInfo) {
+	for position := 0; position < t.NumField(); position++ {
+		var (
+			field      = t.Field(position)
+			key, value = lookupTag(tagName, field)
+			path       = append(append([]int{}, index...), position)
+		)
+
+		if field.Anonymous && value == "" {
+			if embed, ok := embeddedStruct(field); ok {
+				if !visited[embed] {
+					visited[embed] = true
+					collectFields(tagName, embed, path, visited, fields)
+					delete(visited, embed)
+				}
+
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := ParseTag(key, value)
+
+		if tag == nil || tag.Name == "-" {
+			continue
+		}
+
+		info := fieldInfo{
+			name:   field.Name,
+			key:    field.Name,
+			tagKey: key,
+			tag:    value,
+			tagged: value != "",
+			index:  path,
+		}
+
+		// the name of the default tag is the default value
+		if tag.Key != "default" && tag.Name != "" {
+			info.key = tag.Name
+		}
+
+		// the inlined fields do not conflict with each other
+		if tag.Name == "~" {
+			info.key = fmt.Sprintf("~%v", path)
+		}
+
+		*fields = append(*fields, info)
+	}
+}
+
+// fieldsOfType returns the fields of the struct type by their tag names. The
+// fields of the inlined structs are included.
+func fieldsOfType(tagName string, t reflect.Type) map[string]*Field {
+	fields := make(map[string]*Field)
+
+	if t == nil {
+		return fields
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || convertable(t) {
+		return fields
+	}
+
+	for _, field := range StructOf(tagName, reflect.New(t).Elem()).Fields() {
+		if field.Tag.Name == "~" {
+			for name, item := range fieldsOfType(tagName, field.Value.Type()) {
+				fields[name] = item
+			}
+
+			continue
+		}
+
+		fields[field.Tag.Name] = field
+	}
+
+	return fields
+}
+
+// lookupTag returns the first tag key of the list that the field has and its
+// value. The first key is returned if the field has none of them.
+func lookupTag(tagName string, field reflect.StructField) (string, string) {
+	keys := strings.Split(tagName, ",")
+
+	for _, key := range keys {
+		if value, ok := field.Tag.Lookup(key); ok {
+			return key, value
+		}
+	}
+
+	return keys[0], ""
+}
+
+// embeddedStruct returns the struct type of an embedded field that can be
+// promoted
+func embeddedStruct(field reflect.StructField) (reflect.Type, bool) {
+	t := field.Type
+
+	if t.Kind() == reflect.Ptr {
+		// the unexported pointers cannot be allocated
+		if field.PkgPath != "" {
+			return nil, false
+		}
+
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || convertable(t) {
+		return nil, false
+	}
+
+	return t, true
+}
+
+// dominantField returns the field that hides the others with the same name
+func dominantField(fields []fieldInfo) (fieldInfo, bool) {
+	depth := len(fields[0].index)
+
+	for _, info := range fields {
+		if len(info.index) < depth {
+			depth = len(info.index)
+		}
+	}
+
+	var (
+		dominant fieldInfo
+		count    int
+		tagged   int
+	)
+
+	for _, info := range fields {
+		if len(info.index) != depth {
+			continue
+		}
+
+		count++
+
+		if info.tagged {
+			tagged++
+			dominant = info
+		} else if tagged == 0 {
+			dominant = info
+		}
+	}
+
+	if count == 1 || tagged == 1 {
+		return dominant, true
+	}
+
+	return fieldInfo{}, false
+}
+
+// fieldByIndex returns the field of the struct or the embedded nil pointer
+// on the way to it
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, *Field, bool) {
+	var name string
+
+	for depth, position := range index {
+		if depth > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				embed := &Field{
+					Name:  name,
+					Value: value,
+					index: index[:depth],
+				}
+
+				return reflect.Value{}, embed, false
+			}
+
+			value = value.Elem()
+		}
+
+		name = value.Type().Field(position).Name
+		value = value.Field(position)
+	}
+
+	return value, nil, true
+}
+
 // Map return the struct as map
 func (s *Struct) Map() *Map {
 	items := make(map[string]interface{})
@@ -88,27 +358,74 @@ func (s *Struct) tree(ch *Struct, kv map[string]interface{}) {
 			}
 		}
 
+		if field.Tag.HasOption(OptionString) {
+			kv[field.Tag.Name] = quote(field.Value)
+			continue
+		}
+
 		kv[field.Tag.Name] = field.Value.Interface()
 	}
 }
 
-// Array return the struct's fields as array
-func (s *Struct) Array() *Array {
-	var (
-		fields = s.Fields()
-		items  = make([]interface{}, len(fields))
-	)
+// quote returns the booleans and the numbers as strings like the string
+// option of encoding/json does
+func quote(value reflect.Value) interface{} {
+	item := elem(value)
 
-	for _, field := range fields {
-		items = append(items, field.Value.Interface())
+	switch kind(item) {
+	case reflect.Bool, reflect.Int, reflect.Uint, reflect.Float32:
+		return fmt.Sprintf("%v", item.Interface())
 	}
 
+	return value.Interface()
+}
+
+// Array return the struct's fields as array. The fields are positioned by
+// their index option or right after the previous field. The positions of the
+// omitted fields are nil.
+func (s *Struct) Array() *Array {
+	items := []interface{}{}
+	s.array(s, 0, &items)
+
 	return ArrayOf(
 		s.TagName,
 		reflect.ValueOf(items),
 	)
 }
 
+func (s *Struct) array(ch *Struct, next int, items *[]interface{}) int {
+	for _, field := range ch.Fields() {
+		if field.Tag.Name == "~" {
+			// the nil structs are positioned as well to keep the positions
+			// of the following fields
+			value := refer(field.Value)
+
+			if kind(value) == reflect.Struct {
+				next = s.array(StructOf(s.TagName, value), next, items)
+			}
+
+			continue
+		}
+
+		index := field.Index(next)
+		next = index + 1
+
+		if field.Tag.HasOption("omitempty") {
+			if field.IsZero() {
+				continue
+			}
+		}
+
+		for len(*items) <= index {
+			*items = append(*items, nil)
+		}
+
+		(*items)[index] = field.Value.Interface()
+	}
+
+	return next
+}
+
 // Tag defines a single struct's string literal tag
 type Tag struct {
 	Key     string
@@ -127,6 +444,19 @@ func (tag *Tag) HasOption(opt string) bool {
 	return false
 }
 
+// Lookup returns the value of a key=value option
+func (tag *Tag) Lookup(opt string) (string, bool) {
+	for _, key := range tag.Options {
+		parts := strings.SplitN(key, "=", 2)
+
+		if len(parts) == 2 && strings.EqualFold(opt, parts[0]) {
+			return parts[1], true
+		}
+	}
+
+	return "", false
+}
+
 // AddOption adds an option
 func (tag *Tag) AddOption(opt string) {
 	tag.Options = append(tag.Options, opt)
@@ -169,9 +499,43 @@ func ParseTag(key, value string) *Tag {
 
 // Field represents a struct field
 type Field struct {
-	Tag   *Tag
-	Name  string
-	Value reflect.Value
+	Tag     *Tag
+	Name    string
+	Value   reflect.Value
+	index   []int
+	tagName string
+	visible map[string]bool
+}
+
+// inline returns the struct of an inlined field
+func (f *Field) inline(tagName string, value reflect.Value) *Struct {
+	return &Struct{
+		TagName: tagName,
+		Value:   value,
+		visible: f.visible,
+	}
+}
+
+// key returns the tag name list the field was read with
+func (f *Field) key() string {
+	if f.tagName == "" {
+		return f.Tag.Key
+	}
+
+	return f.tagName
+}
+
+// StructField returns the definition of the field in the struct type
+func (f *Field) StructField(t reflect.Type) reflect.StructField {
+	if f.index == nil {
+		field, _ := t.FieldByName(f.Name)
+		return field
+	}
+
+	field := t.FieldByIndex(f.index)
+	// the index of a promoted field starts at the enclosing struct
+	field.Index = append([]int{}, f.index...)
+	return field
 }
 
 // IsZero return true if it's zero
@@ -184,6 +548,18 @@ func (f *Field) IsZero() bool {
 	return reflect.DeepEqual(current, zero)
 }
 
+// Index returns the position of the field in an array. The fields without
+// index option follow the previous field.
+func (f *Field) Index(next int) int {
+	if value, ok := f.Tag.Lookup(OptionIndex); ok {
+		if index, err := strconv.Atoi(value); err == nil && index >= 0 {
+			return index
+		}
+	}
+
+	return next
+}
+
 // Struct returns the field if it's struct
 func (f *Field) Struct() *Struct {
 	value := elem(f.Value)
@@ -193,7 +569,7 @@ func (f *Field) Struct() *Struct {
 	}
 
 	return &Struct{
-		TagName: f.Tag.Key,
+		TagName: f.key(),
 		Value:   value,
 	}
 }
@@ -205,7 +581,7 @@ func (f *Field) Map() *Map {
 	}
 
 	return &Map{
-		TagName: f.Tag.Key,
+		TagName: f.key(),
 		Value:   f.Value,
 	}
 }
@@ -215,7 +591,7 @@ func (f *Field) Array() *Array {
 	switch kind(f.Value) {
 	case reflect.Array, reflect.Slice:
 		return &Array{
-			TagName: f.Tag.Key,
+			TagName: f.key(),
 			Value:   f.Value,
 		}
 	default:
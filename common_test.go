@@ -0,0 +1,185 @@
+package inflate_test
+
+import (
+	"reflect"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Base struct {
+	ID   string `field:"id"`
+	Name string `field:"name"`
+}
+
+type audit struct {
+	CreatedBy string `field:"created_by"`
+}
+
+type Owner struct {
+	Name string `field:"name"`
+}
+
+type Entity struct {
+	*Base
+	audit
+	Owner
+	Name  string `field:"name"`
+	Title string `field:"title"`
+}
+
+var _ = Describe("Struct", func() {
+	names := func(fields []*inflate.Field) []string {
+		result := []string{}
+
+		for _, field := range fields {
+			result = append(result, field.Tag.Name)
+		}
+
+		return result
+	}
+
+	Describe("Fields", func() {
+		It("falls back to the next tag in the list", func() {
+			type Account struct {
+				ID    string `field:"id" json:"account_id"`
+				Email string `json:"email,omitempty"`
+				Phone string
+			}
+
+			fields := inflate.StructOf("field,json", reflect.ValueOf(Account{})).Fields()
+			Expect(names(fields)).To(Equal([]string{"id", "email", "Phone"}))
+			Expect(fields[1].Tag.Key).To(Equal("json"))
+			Expect(fields[1].Tag.HasOption("omitempty")).To(BeTrue())
+		})
+
+		It("promotes the fields of the embedded structs", func() {
+			entity := &Entity{Base: &Base{}}
+
+			fields := inflate.StructOf("field", reflect.ValueOf(entity).Elem()).Fields()
+			Expect(names(fields)).To(Equal([]string{"id", "created_by", "name", "title"}))
+			Expect(fields[2].StructField(reflect.TypeOf(Entity{})).Index).To(Equal([]int{3}))
+
+			fields[0].Value.SetString("5")
+			fields[1].Value.SetString("root")
+			Expect(entity.Base.ID).To(Equal("5"))
+			Expect(entity.audit.CreatedBy).To(Equal("root"))
+		})
+
+		Context("when the embedded pointer is nil", func() {
+			It("returns the embedded struct as inlined field", func() {
+				fields := inflate.StructOf("field", reflect.ValueOf(Entity{})).Fields()
+				Expect(names(fields)).To(Equal([]string{"~", "created_by", "name", "title"}))
+				Expect(fields[0].Name).To(Equal("Base"))
+			})
+		})
+
+		Context("when the embedded fields have the same depth", func() {
+			type Named struct {
+				Name string
+			}
+
+			type Titled struct {
+				Title string `field:"Name"`
+			}
+
+			type Labeled struct {
+				Name string
+			}
+
+			It("returns the tagged field", func() {
+				type Item struct {
+					Named
+					Titled
+				}
+
+				fields := inflate.StructOf("field", reflect.ValueOf(Item{})).Fields()
+				Expect(names(fields)).To(Equal([]string{"Name"}))
+				Expect(fields[0].StructField(reflect.TypeOf(Item{})).Index).To(Equal([]int{1, 0}))
+			})
+
+			It("drops the ambiguous fields", func() {
+				type Item struct {
+					Named
+					Labeled
+				}
+
+				fields := inflate.StructOf("field", reflect.ValueOf(Item{})).Fields()
+				Expect(fields).To(BeEmpty())
+			})
+		})
+
+		Context("when the embedded struct is tagged", func() {
+			type Item struct {
+				Owner `field:"owner"`
+			}
+
+			It("returns the embedded struct as a field", func() {
+				fields := inflate.StructOf("field", reflect.ValueOf(Item{})).Fields()
+				Expect(names(fields)).To(Equal([]string{"owner"}))
+			})
+		})
+	})
+
+	Describe("Array", func() {
+		type Record struct {
+			ID      string `field:"id"`
+			Name    string `field:"name,omitempty"`
+			Count   int    `field:"count,index=3"`
+			Comment string `field:"comment,omitempty"`
+		}
+
+		It("returns the fields at their positions", func() {
+			record := &Record{ID: "a1", Count: 5}
+
+			array := inflate.StructOf("field", reflect.ValueOf(record).Elem()).Array()
+			Expect(array.Value.Interface()).To(Equal([]interface{}{"a1", nil, nil, 5}))
+		})
+	})
+})
+
+var _ = Describe("Embedded", func() {
+	It("converts the map to the promoted fields", func() {
+		source := map[string]interface{}{
+			"id":         "5",
+			"name":       "root",
+			"created_by": "admin",
+		}
+
+		entity := &Entity{}
+		Expect(inflate.Set(entity, &source)).To(Succeed())
+		Expect(entity.Base).To(Equal(&Base{ID: "5"}))
+		Expect(entity.CreatedBy).To(Equal("admin"))
+		Expect(entity.Name).To(Equal("root"))
+		Expect(entity.Owner.Name).To(BeEmpty())
+	})
+
+	It("decodes the promoted fields", func() {
+		type Paging struct {
+			Page int `query:"page"`
+		}
+
+		type Request struct {
+			*Paging
+			Sort string `query:"sort"`
+		}
+
+		request := &Request{}
+		Expect(inflate.NewQueryDecoder(map[string][]string{"page": {"2"}, "sort": {"asc"}}).Decode(request)).To(Succeed())
+		Expect(request.Paging).To(Equal(&Paging{Page: 2}))
+		Expect(request.Sort).To(Equal("asc"))
+	})
+
+	It("diffs the promoted fields of a nil pointer", func() {
+		source := &Entity{Name: "root"}
+		target := &Entity{Base: &Base{ID: "5"}, Name: "root"}
+
+		Expect(inflate.Diff(source, target)).To(ContainElement(inflate.Change{
+			Op:   inflate.ChangeAdd,
+			Path: "/id",
+			To:   "5",
+		}))
+	})
+})
@@ -0,0 +1,141 @@
+package inflate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// NewConfigDecoder creates a config decoder
+func NewConfigDecoder(data map[string]interface{}) *Decoder {
+	return &Decoder{
+		TagName: "config",
+		Converter: &Converter{
+			TagName: "config",
+		},
+		Provider: &ConfigProvider{
+			Data: data,
+		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
+	}
+}
+
+// OpenConfigProvider opens a JSON, YAML or TOML document depending on the
+// file extension
+func OpenConfigProvider(path string) (*ConfigProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return NewJSONProvider(file)
+	case ".yaml", ".yml":
+		return NewYAMLProvider(file)
+	case ".toml":
+		return NewTOMLProvider(file)
+	default:
+		return nil, fmt.Errorf("config: file extension: '%v' not supported", ext)
+	}
+}
+
+// NewJSONProvider creates a config provider from a JSON document
+func NewJSONProvider(reader io.Reader) (*ConfigProvider, error) {
+	var (
+		data    = make(map[string]interface{})
+		decoder = json.NewDecoder(reader)
+	)
+
+	// keeps the precision of the integers
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return &ConfigProvider{Data: data}, nil
+}
+
+// NewYAMLProvider creates a config provider from a YAML document
+func NewYAMLProvider(reader io.Reader) (*ConfigProvider, error) {
+	data := make(map[string]interface{})
+
+	if err := yaml.NewDecoder(reader).Decode(&data); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return &ConfigProvider{Data: data}, nil
+}
+
+// NewTOMLProvider creates a config provider from a TOML document
+func NewTOMLProvider(reader io.Reader) (*ConfigProvider, error) {
+	data := make(map[string]interface{})
+
+	if _, err := toml.NewDecoder(reader).Decode(&data); err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	return &ConfigProvider{Data: data}, nil
+}
+
+var _ ValueProvider = &ConfigProvider{}
+
+// ConfigProvider represents a parameter provider that fetches values from
+// a structured document by their dotted path (e.g. database.host)
+type ConfigProvider struct {
+	Data map[string]interface{}
+}
+
+// Value returns a primitive value
+func (p *ConfigProvider) Value(ctx *Context) (interface{}, error) {
+	if ctx.Tag.Name == "" {
+		return nil, nil
+	}
+
+	value := reflect.ValueOf(p.Data)
+
+	for _, key := range strings.Split(ctx.Tag.Name, ".") {
+		value = elem(value)
+
+		switch value.Kind() {
+		case reflect.Map:
+			value = ctx.Match.or(MatchExact).index(value, key)
+		case reflect.Array, reflect.Slice:
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, p.errorf("field: '%v' index: '%v' invalid", ctx.Tag.Name, key)
+			}
+
+			if index < 0 || index >= value.Len() {
+				return nil, nil
+			}
+
+			value = value.Index(index)
+		default:
+			return nil, nil
+		}
+
+		if !value.IsValid() {
+			return nil, nil
+		}
+	}
+
+	return value.Interface(), nil
+}
+
+func (p *ConfigProvider) errorf(msg string, values ...interface{}) error {
+	msg = fmt.Sprintf(msg, values...)
+	return fmt.Errorf("config: %s", msg)
+}
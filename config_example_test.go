@@ -0,0 +1,33 @@
+package inflate_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phogolabs/inflate"
+)
+
+func ExampleConfigProvider() {
+	type Config struct {
+		Host string `config:"database.host"`
+		Port int    `config:"database.port"`
+	}
+
+	document := strings.NewReader("database:\n  host: localhost\n  port: 5432\n")
+
+	provider, err := inflate.NewYAMLProvider(document)
+	if err != nil {
+		panic(err)
+	}
+
+	config := &Config{}
+
+	if err := inflate.NewConfigDecoder(provider.Data).Decode(config); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%+v", config)
+
+	// Output:
+	// &{Host:localhost Port:5432}
+}
@@ -0,0 +1,234 @@
+package inflate_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	type Database struct {
+		Host    string        `config:"host"`
+		Port    int           `config:"port"`
+		Timeout time.Duration `config:"timeout"`
+	}
+
+	type Config struct {
+		Name     string   `config:"name"`
+		Host     string   `config:"database.host"`
+		Database Database `config:"database"`
+		Tags     []string `config:"tags"`
+		Primary  string   `config:"tags.0"`
+	}
+
+	var expected = &Config{
+		Name: "inflate",
+		Host: "localhost",
+		Database: Database{
+			Host:    "localhost",
+			Port:    5432,
+			Timeout: 1000,
+		},
+		Tags:    []string{"api", "admin"},
+		Primary: "api",
+	}
+
+	Describe("NewJSONProvider", func() {
+		It("decodes the document successfully", func() {
+			provider, err := inflate.NewJSONProvider(strings.NewReader(`{
+				"name": "inflate",
+				"database": {"host": "localhost", "port": 5432, "timeout": 1000},
+				"tags": ["api", "admin"]
+			}`))
+			Expect(err).To(BeNil())
+
+			config := &Config{}
+			Expect(inflate.NewConfigDecoder(provider.Data).Decode(config)).To(Succeed())
+			Expect(config).To(Equal(expected))
+		})
+
+		Context("when the document is invalid", func() {
+			It("returns an error", func() {
+				provider, err := inflate.NewJSONProvider(strings.NewReader("{"))
+				Expect(err).To(MatchError("config: unexpected EOF"))
+				Expect(provider).To(BeNil())
+			})
+		})
+	})
+
+	Describe("NewYAMLProvider", func() {
+		It("decodes the document successfully", func() {
+			provider, err := inflate.NewYAMLProvider(strings.NewReader(strings.Join([]string{
+				"name: inflate",
+				"database:",
+				"  host: localhost",
+				"  port: 5432",
+				"  timeout: 1000",
+				"tags: [api, admin]",
+			}, "\n")))
+			Expect(err).To(BeNil())
+
+			config := &Config{}
+			Expect(inflate.NewConfigDecoder(provider.Data).Decode(config)).To(Succeed())
+			Expect(config).To(Equal(expected))
+		})
+
+		Context("when the document is invalid", func() {
+			It("returns an error", func() {
+				provider, err := inflate.NewYAMLProvider(strings.NewReader("name: [inflate"))
+				Expect(err).To(HaveOccurred())
+				Expect(provider).To(BeNil())
+			})
+		})
+	})
+
+	Describe("NewTOMLProvider", func() {
+		It("decodes the document successfully", func() {
+			provider, err := inflate.NewTOMLProvider(strings.NewReader(strings.Join([]string{
+				`name = "inflate"`,
+				`tags = ["api", "admin"]`,
+				`[database]`,
+				`host = "localhost"`,
+				`port = 5432`,
+				`timeout = 1000`,
+			}, "\n")))
+			Expect(err).To(BeNil())
+
+			config := &Config{}
+			Expect(inflate.NewConfigDecoder(provider.Data).Decode(config)).To(Succeed())
+			Expect(config).To(Equal(expected))
+		})
+
+		Context("when the document is invalid", func() {
+			It("returns an error", func() {
+				provider, err := inflate.NewTOMLProvider(strings.NewReader("name ="))
+				Expect(err).To(HaveOccurred())
+				Expect(provider).To(BeNil())
+			})
+		})
+	})
+
+	Describe("OpenConfigProvider", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		It("opens the document by its extension", func() {
+			path := filepath.Join(dir, "config.yml")
+			Expect(os.WriteFile(path, []byte("name: inflate"), 0o600)).To(Succeed())
+
+			provider, err := inflate.OpenConfigProvider(path)
+			Expect(err).To(BeNil())
+			Expect(provider.Data).To(HaveKeyWithValue("name", "inflate"))
+		})
+
+		Context("when the extension is not supported", func() {
+			It("returns an error", func() {
+				path := filepath.Join(dir, "config.ini")
+				Expect(os.WriteFile(path, []byte("name=inflate"), 0o600)).To(Succeed())
+
+				provider, err := inflate.OpenConfigProvider(path)
+				Expect(err).To(MatchError("config: file extension: '.ini' not supported"))
+				Expect(provider).To(BeNil())
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				provider, err := inflate.OpenConfigProvider(filepath.Join(dir, "config.json"))
+				Expect(err).To(HaveOccurred())
+				Expect(provider).To(BeNil())
+			})
+		})
+	})
+
+	Describe("ConfigProvider", func() {
+		var (
+			provider *inflate.ConfigProvider
+			ctx      *inflate.Context
+		)
+
+		BeforeEach(func() {
+			ctx = &inflate.Context{
+				Field: "Host",
+				Type:  reflect.TypeOf(""),
+				Tag: &inflate.Tag{
+					Key:  "config",
+					Name: "database.host",
+				},
+			}
+
+			provider = &inflate.ConfigProvider{
+				Data: map[string]interface{}{
+					"database": map[string]interface{}{
+						"host": "localhost",
+					},
+					"tags": []interface{}{"api"},
+				},
+			}
+		})
+
+		It("returns the value successfully", func() {
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("localhost"))
+		})
+
+		Context("when the path does not exist", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "database.host.name"
+			})
+
+			It("returns a nil value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(BeNil())
+			})
+		})
+
+		Context("when the path has an index", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "tags.0"
+			})
+
+			It("returns the value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(Equal("api"))
+			})
+
+			Context("when the index is out of range", func() {
+				BeforeEach(func() {
+					ctx.Tag.Name = "tags.1"
+				})
+
+				It("returns a nil value successfully", func() {
+					value, err := provider.Value(ctx)
+					Expect(err).To(BeNil())
+					Expect(value).To(BeNil())
+				})
+			})
+
+			Context("when the index is invalid", func() {
+				BeforeEach(func() {
+					ctx.Tag.Name = "tags.first"
+				})
+
+				It("returns an error", func() {
+					value, err := provider.Value(ctx)
+					Expect(err).To(MatchError("config: field: 'tags.first' index: 'first' invalid"))
+					Expect(value).To(BeNil())
+				})
+			})
+		})
+	})
+})
@@ -8,11 +8,19 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 )
 
+var durationType = reflect.TypeOf(time.Duration(0))
+
 // Converter represents a decoder
 type Converter struct {
 	TagName string
+	// DeepCopy allocates new maps, slices and pointers instead of assigning
+	// the source ones when the source and the target have the same type
+	DeepCopy bool
+	// Match defines how the field names are matched with the map keys
+	Match NameMatch
 }
 
 // Convert converts a value to another value
@@ -37,9 +45,51 @@ func (d *Converter) convert(source, target reflect.Value) (err error) {
 	}
 
 	if source.Type() == target.Type() {
+		if d.DeepCopy {
+			copies := make(map[uintptr]reflect.Value)
+
+			// the pointers to the source itself are copied as pointers to
+			// the target
+			if source.CanAddr() && target.CanAddr() {
+				copies[source.Addr().Pointer()] = target.Addr()
+			}
+
+			d.copy(source, target, copies)
+			return nil
+		}
+
 		return set(target, source)
 	}
 
+	if setter, ok := optionalOf(target); ok {
+		return d.convertToOptional(source, setter)
+	}
+
+	if enum, ok := enumOf(target.Type()); ok && kind(source) == reflect.String {
+		return d.convertToEnum(source, target, enum)
+	}
+
+	if enum, ok := enumOf(source.Type()); ok && kind(target) == reflect.String {
+		if name, ok := enum.Name(source.Interface()); ok {
+			target.SetString(name)
+			return nil
+		}
+	}
+
+	if getter, ok := optionalValueOf(source); ok {
+		value, present, valid := getter.optional()
+
+		switch {
+		case !present:
+			return d.convert(reflect.Value{}, target)
+		case !valid:
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		default:
+			return d.convert(value, target)
+		}
+	}
+
 	switch kind(target) {
 	case reflect.String:
 		err = d.convertToString(source, target)
@@ -79,6 +129,11 @@ func (d *Converter) convertToString(source, target reflect.Value) error {
 			target.SetString("0")
 		}
 	case reflect.Int:
+		if source.Type() == durationType {
+			target.SetString(time.Duration(source.Int()).String())
+			return nil
+		}
+
 		target.SetString(strconv.FormatInt(source.Int(), 10))
 	case reflect.Uint:
 		target.SetString(strconv.FormatUint(source.Uint(), 10))
@@ -95,8 +150,7 @@ func (d *Converter) convertToString(source, target reflect.Value) error {
 
 		value, ok, err := d.valueRead(source)
 		if ok && err == nil {
-			source = elem(reflect.ValueOf(value))
-			return d.convertToString(source, target)
+			return d.convert(elem(reflect.ValueOf(value)), target)
 		}
 
 		return rerror(source, target, err)
@@ -129,8 +183,7 @@ func (d *Converter) convertToBool(source, target reflect.Value) error {
 	default:
 		value, ok, err := d.valueRead(source)
 		if ok && err == nil {
-			source = elem(reflect.ValueOf(value))
-			return d.convertToBool(source, target)
+			return d.convert(elem(reflect.ValueOf(value)), target)
 		}
 
 		return rerror(source, target, err)
@@ -159,15 +212,20 @@ func (d *Converter) convertToInt(source, target reflect.Value) error {
 		switch {
 		case err == nil:
 			target.SetInt(value)
+		case target.Type() == durationType:
+			duration, err := time.ParseDuration(source.String())
+			if err != nil {
+				return rerror(source, target, err)
+			}
+
+			target.SetInt(int64(duration))
 		default:
 			return rerror(source, target, err)
 		}
 	default:
 		value, ok, err := d.valueRead(source)
 		if ok && err == nil {
-
-			source = elem(reflect.ValueOf(value))
-			return d.convertToInt(source, target)
+			return d.convert(elem(reflect.ValueOf(value)), target)
 		}
 
 		return rerror(source, target, err)
@@ -202,8 +260,7 @@ func (d *Converter) convertToUint(source, target reflect.Value) error {
 	default:
 		value, ok, err := d.valueRead(source)
 		if ok && err == nil {
-			source = elem(reflect.ValueOf(value))
-			return d.convertToUint(source, target)
+			return d.convert(elem(reflect.ValueOf(value)), target)
 		}
 
 		return rerror(source, target, err)
@@ -238,8 +295,7 @@ func (d *Converter) convertToFloat(source, target reflect.Value) error {
 	default:
 		value, ok, err := d.valueRead(source)
 		if ok && err == nil {
-			source = elem(reflect.ValueOf(value))
-			return d.convertToFloat(source, target)
+			return d.convert(elem(reflect.ValueOf(value)), target)
 		}
 
 		return rerror(source, target, err)
@@ -260,13 +316,30 @@ func (d *Converter) convertToStruct(source, target reflect.Value) error {
 			MapOf(d.TagName, source),
 			StructOf(d.TagName, target),
 		)
+	case reflect.Array:
+		// the scanners and the text unmarshalers convert the arrays themselves
+		if !convertable(target.Type()) {
+			return d.convertStructFromArray(
+				ArrayOf(d.TagName, source),
+				StructOf(d.TagName, target),
+			)
+		}
 	case reflect.Slice:
-		if source.Type().Elem().Kind() == reflect.Uint8 {
-			if data := source.Bytes(); json.Valid(data) {
-				if value := refer(target); value.CanAddr() {
-					if err := json.Unmarshal(data, value.Addr().Interface()); err == nil {
-						return set(target, value)
-					}
+		if source.Type().Elem().Kind() != reflect.Uint8 {
+			if convertable(target.Type()) {
+				break
+			}
+
+			return d.convertStructFromArray(
+				ArrayOf(d.TagName, source),
+				StructOf(d.TagName, target),
+			)
+		}
+
+		if data := source.Bytes(); json.Valid(data) {
+			if value := refer(target); value.CanAddr() {
+				if err := json.Unmarshal(data, value.Addr().Interface()); err == nil {
+					return set(target, value)
 				}
 			}
 		}
@@ -278,6 +351,11 @@ func (d *Converter) convertToStruct(source, target reflect.Value) error {
 	}
 
 	ok, err := d.valueScan(source.Interface(), target)
+	if ok && err != nil && kind(source) == reflect.String && source.String() == "" {
+		// the present but empty values are scanned as NULL
+		ok, err = d.valueScan(nil, target)
+	}
+
 	if ok && err == nil {
 		return nil
 	}
@@ -292,7 +370,7 @@ func (d *Converter) convertStructFromMap(source *Map, target *Struct) error {
 
 			switch kind(value) {
 			case reflect.Struct:
-				obj := StructOf(d.TagName, value)
+				obj := field.inline(d.TagName, value)
 
 				if err := d.convertStructFromMap(source, obj); err != nil {
 					return rerrorf(field.Name, err)
@@ -322,6 +400,10 @@ func (d *Converter) convertStructFromMap(source *Map, target *Struct) error {
 
 		item := elem(source.Get(key))
 
+		if !item.IsValid() && d.Match.or(MatchExact) != MatchExact {
+			item = elem(d.Match.index(source.Value, field.Tag.Name))
+		}
+
 		if !item.IsValid() {
 			continue
 		}
@@ -340,6 +422,60 @@ func (d *Converter) convertStructFromMap(source *Map, target *Struct) error {
 	return nil
 }
 
+func (d *Converter) convertStructFromArray(source *Array, target *Struct) error {
+	_, err := d.convertStructFromArrayAt(source, target, 0)
+	return err
+}
+
+func (d *Converter) convertStructFromArrayAt(source *Array, target *Struct, next int) (int, error) {
+	for _, field := range target.Fields() {
+		if field.Tag.Name == "~" {
+			value := refer(field.Value)
+
+			if kind(value) != reflect.Struct {
+				return next, rerrorf(field.Name, rerror(source.Value, target.Value, nil))
+			}
+
+			index, err := d.convertStructFromArrayAt(source, StructOf(d.TagName, value), next)
+			if err != nil {
+				return next, rerrorf(field.Name, err)
+			}
+
+			if err := set(field.Value, value.Addr()); err != nil {
+				return next, err
+			}
+
+			next = index
+			continue
+		}
+
+		index := field.Index(next)
+		next = index + 1
+
+		if index >= source.Value.Len() {
+			continue
+		}
+
+		item := elem(source.Value.Index(index))
+
+		if !item.IsValid() {
+			continue
+		}
+
+		converted := refer(field.Value)
+
+		if err := d.convert(item, converted); err != nil {
+			return next, err
+		}
+
+		if err := set(field.Value, converted); err != nil {
+			return next, err
+		}
+	}
+
+	return next, nil
+}
+
 func (d *Converter) convertToMap(source, target reflect.Value) error {
 	switch kind(source) {
 	case reflect.Map:
@@ -370,11 +506,15 @@ func (d *Converter) convertToMap(source, target reflect.Value) error {
 }
 
 func (d *Converter) convertMapFromMap(source *Map, target *Map) error {
-	if source.Value.Type() == target.Value.Type() {
+	if source.Value.Type() == target.Value.Type() && !d.DeepCopy {
 		target.Value.Set(source.Value)
 		return nil
 	}
 
+	if target.Value.IsNil() {
+		target.Value.Set(reflect.MakeMap(target.Value.Type()))
+	}
+
 	iter := source.Value.MapRange()
 
 	for iter.Next() {
@@ -430,7 +570,7 @@ func (d *Converter) convertToArray(source, target reflect.Value) error {
 			}
 		}
 
-		return d.convertArrayFromArray(
+		return d.convertArrayFromStruct(
 			StructOf(d.TagName, source).Array(),
 			ArrayOf(d.TagName, target),
 		)
@@ -470,6 +610,35 @@ func (d *Converter) convertArrayFromArray(source *Array, target *Array) error {
 	return nil
 }
 
+func (d *Converter) convertArrayFromStruct(source *Array, target *Array) error {
+	// the zero values are kept so the items stay at the positions of the fields
+	for index := 0; index < source.Value.Len(); index++ {
+		var (
+			item      = elem(source.Value.Index(index))
+			converted = create(target.Elem)
+		)
+
+		if err := d.convert(item, converted); err != nil {
+			return err
+		}
+
+		switch kind(target.Value) {
+		case reflect.Array:
+			if index >= target.Value.Len() {
+				return nil
+			}
+
+			if err := set(target.Value.Index(index), converted); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			target.Append(converted)
+		}
+	}
+
+	return nil
+}
+
 func (d *Converter) convertToPtr(source, target reflect.Value) error {
 	origin := target
 
@@ -486,6 +655,68 @@ func (d *Converter) convertToPtr(source, target reflect.Value) error {
 	return set(origin, target)
 }
 
+func (d *Converter) convertToOptional(source reflect.Value, target optionalSetter) error {
+	if getter, ok := optionalValueOf(source); ok {
+		value, present, valid := getter.optional()
+
+		switch {
+		case !present:
+			return nil
+		case !valid:
+			target.setOptional(reflect.Value{})
+			return nil
+		default:
+			source = value
+		}
+	}
+
+	if kind(source) == reflect.String && source.String() == "" {
+		target.setOptional(reflect.Value{})
+		return nil
+	}
+
+	value := reflect.New(target.optionalType()).Elem()
+
+	if err := d.convert(source, value); err != nil {
+		return err
+	}
+
+	target.setOptional(value)
+	return nil
+}
+
+var enumNumbers = map[reflect.Kind]reflect.Type{
+	reflect.Int:     reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float64(0)),
+}
+
+func (d *Converter) convertToEnum(source, target reflect.Value, enum *Enum) error {
+	value, ok := enum.Value(source.String())
+
+	if !ok {
+		// the values that are not named are converted like numbers the same
+		// way the unnamed values are converted to strings
+		if number, ok := enumNumbers[kind(target)]; ok {
+			item := reflect.New(number).Elem()
+
+			if err := d.convert(source, item); err == nil {
+				target.Set(item.Convert(target.Type()))
+				return nil
+			}
+		}
+
+		return rerror(source, target, &EnumError{
+			Type:    enum.Type,
+			Name:    source.String(),
+			Allowed: enum.Names,
+		})
+	}
+
+	target.Set(reflect.ValueOf(value))
+	return nil
+}
+
 func (d *Converter) convertToBasic(source, target reflect.Value) error {
 	if !source.IsValid() {
 		source = create(source.Type())
@@ -505,6 +736,104 @@ func (d *Converter) convertToBasic(source, target reflect.Value) error {
 	return set(target, source)
 }
 
+func (d *Converter) copy(source, target reflect.Value, copies map[uintptr]reflect.Value) {
+	switch source.Kind() {
+	case reflect.Ptr:
+		if source.IsNil() {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+
+		// the cycles are copied by the pointer that has been already allocated
+		if value, ok := copies[source.Pointer()]; ok && value.Type() == target.Type() {
+			target.Set(value)
+			return
+		}
+
+		value := reflect.New(source.Type().Elem())
+		copies[source.Pointer()] = value
+
+		d.copy(source.Elem(), value.Elem(), copies)
+		target.Set(value)
+	case reflect.Interface:
+		if source.IsNil() {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+
+		value := reflect.New(source.Elem().Type()).Elem()
+
+		d.copy(source.Elem(), value, copies)
+		target.Set(value)
+	case reflect.Map:
+		if source.IsNil() {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+
+		if value, ok := copies[source.Pointer()]; ok && value.Type() == target.Type() {
+			target.Set(value)
+			return
+		}
+
+		value := reflect.MakeMapWithSize(source.Type(), source.Len())
+		copies[source.Pointer()] = value
+
+		iter := source.MapRange()
+
+		for iter.Next() {
+			var (
+				key  = reflect.New(source.Type().Key()).Elem()
+				item = reflect.New(source.Type().Elem()).Elem()
+			)
+
+			d.copy(iter.Key(), key, copies)
+			d.copy(iter.Value(), item, copies)
+
+			value.SetMapIndex(key, item)
+		}
+
+		target.Set(value)
+	case reflect.Slice:
+		if source.IsNil() {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+
+		value := reflect.MakeSlice(source.Type(), source.Len(), source.Len())
+
+		for index := 0; index < source.Len(); index++ {
+			d.copy(source.Index(index), value.Index(index), copies)
+		}
+
+		target.Set(value)
+	case reflect.Array:
+		value := reflect.New(source.Type()).Elem()
+
+		for index := 0; index < source.Len(); index++ {
+			d.copy(source.Index(index), value.Index(index), copies)
+		}
+
+		target.Set(value)
+	case reflect.Struct:
+		// the unexported fields are copied as they are
+		value := reflect.New(source.Type()).Elem()
+		value.Set(source)
+
+		for index := 0; index < source.NumField(); index++ {
+			if source.Type().Field(index).PkgPath != "" {
+				continue
+			}
+
+			d.copy(source.Field(index), value.Field(index), copies)
+		}
+
+		target.Set(value)
+	default:
+		target.Set(source)
+	}
+}
+
 func (d *Converter) textMarshal(source reflect.Value) (string, bool, error) {
 	targetType := reflect.TypeOf(new(encoding.TextMarshaler)).Elem()
 
@@ -539,6 +868,8 @@ func (d *Converter) textUnmarshal(data string, target reflect.Value) (bool, erro
 	return false, nil
 }
 
+// valueRead returns the value of a driver.Valuer. The NULL values are nil so
+// they are converted to the zero value of the target.
 func (d *Converter) valueRead(source reflect.Value) (interface{}, bool, error) {
 	targetType := reflect.TypeOf(new(driver.Valuer)).Elem()
 
@@ -618,6 +618,76 @@ var _ = Describe("Converter", func() {
 			})
 		})
 
+		Context("when the source value is slice", func() {
+			type Meta struct {
+				Comment string `fake:"comment,index=4"`
+			}
+
+			type Record struct {
+				ID    string `fake:"id"`
+				Count int    `fake:"count,index=2"`
+				Name  string `fake:"name"`
+				Meta  *Meta  `fake:"~"`
+			}
+
+			It("converts the value successfully", func() {
+				source := []string{"a1", "ignored", "5", "John", "hello"}
+
+				target := Record{}
+				Expect(converter.Convert(&source, &target)).To(Succeed())
+				Expect(target).To(Equal(Record{
+					ID:    "a1",
+					Count: 5,
+					Name:  "John",
+					Meta:  &Meta{Comment: "hello"},
+				}))
+			})
+
+			Context("when the source is shorter", func() {
+				It("converts the available items", func() {
+					source := []interface{}{"a1", nil, 5}
+
+					target := Record{}
+					Expect(converter.Convert(&source, &target)).To(Succeed())
+					Expect(target.ID).To(Equal("a1"))
+					Expect(target.Count).To(Equal(5))
+					Expect(target.Name).To(BeEmpty())
+				})
+			})
+
+			Context("when the target is converted back", func() {
+				It("keeps the positions of the fields", func() {
+					source := Record{
+						ID:   "a1",
+						Name: "John",
+					}
+
+					target := []string{}
+					Expect(converter.Convert(&source, &target)).To(Succeed())
+					Expect(target).To(Equal([]string{"a1", "", "0", "John", ""}))
+				})
+			})
+
+			Context("when the target is a scanner", func() {
+				It("returns an error", func() {
+					source := []interface{}{"hello"}
+
+					target := sql.NullString{}
+					Expect(converter.Convert(&source, &target)).To(MatchError("cannot convert slice '[hello]' to struct: unsupported Scan, storing driver.Value type []interface {} into type *string"))
+					Expect(target.Valid).To(BeFalse())
+				})
+			})
+
+			Context("when the item cannot be converted", func() {
+				It("returns an error", func() {
+					source := []string{"a1", "", "many"}
+
+					target := Record{}
+					Expect(converter.Convert(&source, &target)).To(MatchError(`cannot convert string 'many' to int: strconv.ParseInt: parsing "many": invalid syntax`))
+				})
+			})
+		})
+
 		Context("when the source value is equal to the target", func() {
 			var source Text
 
@@ -1113,4 +1183,39 @@ var _ = Describe("Converter", func() {
 			})
 		})
 	})
+
+	Context("when the target is a duration", func() {
+		It("parses the duration successfully", func() {
+			source := "1m30s"
+			target := time.Duration(0)
+
+			Expect(converter.Convert(&source, &target)).To(Succeed())
+			Expect(target).To(Equal(90 * time.Second))
+		})
+
+		It("parses the nanoseconds successfully", func() {
+			source := "1000"
+			target := time.Duration(0)
+
+			Expect(converter.Convert(&source, &target)).To(Succeed())
+			Expect(target).To(Equal(time.Duration(1000)))
+		})
+
+		It("formats the duration successfully", func() {
+			source := 90 * time.Second
+			target := ""
+
+			Expect(converter.Convert(&source, &target)).To(Succeed())
+			Expect(target).To(Equal("1m30s"))
+		})
+
+		Context("when the value cannot be parsed", func() {
+			It("returns an error", func() {
+				source := "soon"
+				target := time.Duration(0)
+
+				Expect(converter.Convert(&source, &target)).To(MatchError("cannot convert string 'soon' to int: time: invalid duration \"soon\""))
+			})
+		})
+	})
 })
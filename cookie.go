@@ -23,6 +23,9 @@ func NewCookieDecoder(cookies []*http.Cookie) *Decoder {
 		Provider: &CookieProvider{
 			Cookies: cookies,
 		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
 	}
 }
 
@@ -57,8 +60,29 @@ func (p *CookieProvider) Value(ctx *Context) (interface{}, error) {
 	}
 }
 
+// Check checks whether the options are supported for the field's type
+func (p *CookieProvider) Check(ctx *Context) error {
+	if ctx.Tag.Name == "" {
+		return nil
+	}
+
+	if len(ctx.Tag.Options) == 0 {
+		return nil
+	}
+
+	if !ctx.Tag.HasOption(OptionForm) {
+		return p.notProvided(ctx, OptionForm)
+	}
+
+	if shape(ctx.Type) != reflect.String && ctx.Tag.HasOption(OptionExplode) {
+		return p.notSupported(ctx, OptionExplode)
+	}
+
+	return nil
+}
+
 func (p *CookieProvider) valueOf(ctx *Context) (interface{}, error) {
-	cookie := p.cookie(ctx.Tag.Name)
+	cookie := p.cookie(ctx)
 
 	if cookie == nil {
 		return nil, nil
@@ -72,7 +96,7 @@ func (p *CookieProvider) valueOf(ctx *Context) (interface{}, error) {
 }
 
 func (p *CookieProvider) arrayOf(ctx *Context) ([]interface{}, error) {
-	cookie := p.cookie(ctx.Tag.Name)
+	cookie := p.cookie(ctx)
 
 	if cookie == nil {
 		return nil, nil
@@ -100,7 +124,7 @@ func (p *CookieProvider) arrayOf(ctx *Context) ([]interface{}, error) {
 }
 
 func (p *CookieProvider) mapOf(ctx *Context) (map[string]interface{}, error) {
-	cookie := p.cookie(ctx.Tag.Name)
+	cookie := p.cookie(ctx)
 
 	if cookie == nil {
 		return nil, nil
@@ -127,9 +151,21 @@ func (p *CookieProvider) mapOf(ctx *Context) (map[string]interface{}, error) {
 	return m, nil
 }
 
-func (p *CookieProvider) cookie(name string) *http.Cookie {
+func (p *CookieProvider) cookie(ctx *Context) *http.Cookie {
+	names := make([]string, len(p.Cookies))
+
+	for index, cookie := range p.Cookies {
+		names[index] = cookie.Name
+	}
+
+	key, ok := ctx.Match.or(MatchFold).find(ctx.Tag.Name, names)
+
+	if !ok {
+		return nil
+	}
+
 	for _, cookie := range p.Cookies {
-		if strings.EqualFold(cookie.Name, name) {
+		if cookie.Name == key {
 			return cookie
 		}
 	}
@@ -0,0 +1,122 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/phogolabs/inflate"
+)
+
+// NewDecoder creates a decoder that reads the records from r. The first
+// record is the header.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		Reader: csv.NewReader(r),
+		Header: true,
+		Converter: &inflate.Converter{
+			TagName: "csv",
+		},
+	}
+}
+
+// Decoder reads the CSV records and decodes them into structs. The columns
+// are matched to the csv tags by the header names or by the field positions
+// when there is no header.
+type Decoder struct {
+	Reader    *csv.Reader
+	Header    bool
+	Converter inflate.ValueConverter
+	header    []string
+}
+
+// Decode decodes the next record to given target. It returns io.EOF when
+// there are no more records.
+func (d *Decoder) Decode(target interface{}) error {
+	if d.Header && d.header == nil {
+		header, err := d.Reader.Read()
+		if err != nil {
+			return d.errorf(err)
+		}
+
+		d.header = header
+	}
+
+	record, err := d.Reader.Read()
+	if err != nil {
+		return d.errorf(err)
+	}
+
+	line, _ := d.Reader.FieldPos(0)
+
+	if err := d.convert(record, target); err != nil {
+		return fmt.Errorf("csv: line: %v %w", line, err)
+	}
+
+	return nil
+}
+
+// DecodeAll decodes all records to given slice
+func (d *Decoder) DecodeAll(target interface{}) error {
+	value := reflect.ValueOf(target)
+
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("csv: target: %T is not a pointer to slice", target)
+	}
+
+	var (
+		items = value.Elem()
+		kind  = items.Type().Elem()
+	)
+
+	for {
+		item := reflect.New(kind)
+
+		if kind.Kind() == reflect.Ptr {
+			item.Elem().Set(reflect.New(kind.Elem()))
+		}
+
+		switch err := d.Decode(item.Interface()); err {
+		case nil:
+			items.Set(reflect.Append(items, item.Elem()))
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+func (d *Decoder) convert(record []string, target interface{}) error {
+	// the empty cells are treated as missing values
+	if d.header == nil {
+		values := make([]interface{}, len(record))
+
+		for index, cell := range record {
+			if cell != "" {
+				values[index] = cell
+			}
+		}
+
+		return d.Converter.Convert(&values, target)
+	}
+
+	values := make(map[string]interface{})
+
+	for index, cell := range record {
+		if index < len(d.header) && cell != "" {
+			values[d.header[index]] = cell
+		}
+	}
+
+	return d.Converter.Convert(&values, target)
+}
+
+func (d *Decoder) errorf(err error) error {
+	if err == io.EOF {
+		return err
+	}
+
+	return fmt.Errorf("csv: %w", err)
+}
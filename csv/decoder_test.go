@@ -0,0 +1,94 @@
+package csv_test
+
+import (
+	"io"
+	"strings"
+
+	"github.com/phogolabs/inflate/csv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Decoder", func() {
+	var input string
+
+	BeforeEach(func() {
+		input = strings.Join([]string{
+			"name,id,count,enabled,score,created_at",
+			"John,1,10,true,0.5,2022-01-01",
+			"Jack,2,,false,,",
+		}, "\n")
+	})
+
+	It("decodes the records by header successfully", func() {
+		decoder := csv.NewDecoder(strings.NewReader(input))
+
+		sale := &Sale{}
+		Expect(decoder.Decode(sale)).To(Succeed())
+		Expect(sale).To(Equal(&Sale{
+			ID:      "1",
+			Name:    "John",
+			Count:   10,
+			Enabled: true,
+			Score:   0.5,
+			Audit:   &Audit{CreatedAt: "2022-01-01"},
+		}))
+
+		sale = &Sale{}
+		Expect(decoder.Decode(sale)).To(Succeed())
+		Expect(sale.ID).To(Equal("2"))
+		Expect(sale.Count).To(Equal(0))
+
+		Expect(decoder.Decode(&Sale{})).To(Equal(io.EOF))
+	})
+
+	It("decodes all records successfully", func() {
+		sales := []*Sale{}
+
+		decoder := csv.NewDecoder(strings.NewReader(input))
+		Expect(decoder.DecodeAll(&sales)).To(Succeed())
+		Expect(sales).To(HaveLen(2))
+		Expect(sales[0].Name).To(Equal("John"))
+		Expect(sales[1].Name).To(Equal("Jack"))
+	})
+
+	Context("when there is no header", func() {
+		It("decodes the records by position successfully", func() {
+			decoder := csv.NewDecoder(strings.NewReader("1,John,10,true,0.5,2022-01-01"))
+			decoder.Header = false
+
+			sales := []Sale{}
+			Expect(decoder.DecodeAll(&sales)).To(Succeed())
+			Expect(sales).To(ConsistOf(Sale{
+				ID:      "1",
+				Name:    "John",
+				Count:   10,
+				Enabled: true,
+				Score:   0.5,
+				Audit:   &Audit{CreatedAt: "2022-01-01"},
+			}))
+		})
+	})
+
+	Context("when the cell cannot be converted", func() {
+		It("returns an error", func() {
+			decoder := csv.NewDecoder(strings.NewReader("id,count\n1,many"))
+			Expect(decoder.Decode(&Sale{})).To(MatchError(`csv: line: 2 cannot convert string 'many' to int: strconv.ParseInt: parsing "many": invalid syntax`))
+		})
+	})
+
+	Context("when the record is invalid", func() {
+		It("returns an error", func() {
+			decoder := csv.NewDecoder(strings.NewReader("id,name\n1"))
+			Expect(decoder.Decode(&Sale{})).To(MatchError("csv: record on line 2: wrong number of fields"))
+		})
+	})
+
+	Context("when the target is not a slice", func() {
+		It("returns an error", func() {
+			decoder := csv.NewDecoder(strings.NewReader(input))
+			Expect(decoder.DecodeAll(&Sale{})).To(MatchError("csv: target: *csv_test.Sale is not a pointer to slice"))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/phogolabs/inflate"
+)
+
+// NewEncoder creates an encoder that writes the records to w. The header is
+// written before the first record.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		Writer: csv.NewWriter(w),
+		Header: true,
+		Converter: &inflate.Converter{
+			TagName: "csv",
+		},
+	}
+}
+
+// Encoder encodes structs as CSV records. The cells are positioned like the
+// csv tagged fields of the struct.
+type Encoder struct {
+	Writer    *csv.Writer
+	Header    bool
+	Converter inflate.ValueConverter
+	written   bool
+}
+
+// Encode writes the source as a record
+func (e *Encoder) Encode(source interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(source))
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("csv: source: %T is not a struct", source)
+	}
+
+	if e.Header && !e.written {
+		if err := e.Writer.Write(Header(value.Type())); err != nil {
+			return fmt.Errorf("csv: %w", err)
+		}
+	}
+
+	e.written = true
+
+	var (
+		record = []string{}
+		item   = reflect.New(value.Type())
+	)
+
+	item.Elem().Set(value)
+
+	if err := e.Converter.Convert(item.Interface(), &record); err != nil {
+		return fmt.Errorf("csv: %w", err)
+	}
+
+	// the omitted trailing fields are written as empty cells
+	for count := len(Header(value.Type())); len(record) < count; {
+		record = append(record, "")
+	}
+
+	if err := e.Writer.Write(record); err != nil {
+		return fmt.Errorf("csv: %w", err)
+	}
+
+	e.Writer.Flush()
+
+	if err := e.Writer.Error(); err != nil {
+		return fmt.Errorf("csv: %w", err)
+	}
+
+	return nil
+}
+
+// EncodeAll writes the items of the source slice as records
+func (e *Encoder) EncodeAll(source interface{}) error {
+	items := reflect.Indirect(reflect.ValueOf(source))
+
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return fmt.Errorf("csv: source: %T is not a slice", source)
+	}
+
+	for index := 0; index < items.Len(); index++ {
+		if err := e.Encode(items.Index(index).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Header returns the csv tag names of the struct at their positions
+func Header(kind reflect.Type) []string {
+	header := []string{}
+	header, _ = columns(reflect.New(kind).Elem(), header, 0)
+	return header
+}
+
+func columns(value reflect.Value, header []string, next int) ([]string, int) {
+	for _, field := range inflate.StructOf("csv", value).Fields() {
+		if field.Tag.Name == "~" {
+			item := field.Value
+
+			if item.Kind() == reflect.Ptr {
+				item = reflect.New(item.Type().Elem()).Elem()
+			}
+
+			if item.Kind() == reflect.Struct {
+				header, next = columns(item, header, next)
+			}
+
+			continue
+		}
+
+		index := field.Index(next)
+		next = index + 1
+
+		for len(header) <= index {
+			header = append(header, "")
+		}
+
+		header[index] = field.Tag.Name
+	}
+
+	return header, next
+}
@@ -0,0 +1,111 @@
+package csv_test
+
+import (
+	"bytes"
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/phogolabs/inflate/csv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encoder", func() {
+	var buffer *bytes.Buffer
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+	})
+
+	It("encodes the records successfully", func() {
+		sales := []Sale{
+			{ID: "1", Name: "John", Count: 10, Enabled: true, Score: 0.5, Audit: &Audit{CreatedAt: "2022-01-01"}},
+			{ID: "2", Name: "Jack"},
+		}
+
+		encoder := csv.NewEncoder(buffer)
+		Expect(encoder.EncodeAll(sales)).To(Succeed())
+		Expect(buffer.String()).To(Equal("id,name,count,enabled,score,created_at\n1,John,10,1,0.5,2022-01-01\n2,Jack,0,0,0,\n"))
+	})
+
+	It("encodes the records that are decoded back", func() {
+		sale := &Sale{ID: "1", Name: "John, Jr.", Count: 10, Audit: &Audit{CreatedAt: "2022-01-01"}}
+
+		Expect(csv.NewEncoder(buffer).Encode(sale)).To(Succeed())
+
+		decoded := &Sale{}
+		Expect(csv.NewDecoder(buffer).Decode(decoded)).To(Succeed())
+		Expect(decoded).To(Equal(sale))
+	})
+
+	Context("when the last field is omitted", func() {
+		type Comment struct {
+			ID   string `csv:"id"`
+			Note string `csv:"note,omitempty"`
+		}
+
+		It("encodes the records that are decoded back", func() {
+			comments := []Comment{{ID: "1", Note: "a"}, {ID: "2"}}
+
+			Expect(csv.NewEncoder(buffer).EncodeAll(comments)).To(Succeed())
+			Expect(buffer.String()).To(Equal("id,note\n1,a\n2,\n"))
+
+			decoded := []Comment{}
+			Expect(csv.NewDecoder(buffer).DecodeAll(&decoded)).To(Succeed())
+			Expect(decoded).To(Equal(comments))
+		})
+	})
+
+	Context("when the columns are nullable", func() {
+		type Task struct {
+			ID      string         `csv:"id"`
+			Owner   sql.NullString `csv:"owner"`
+			Timeout time.Duration  `csv:"timeout"`
+		}
+
+		It("encodes the records that are decoded back", func() {
+			decoded := []Task{}
+
+			decoder := csv.NewDecoder(strings.NewReader("id,owner,timeout\n1,,5s\n2,root,1m30s\n"))
+			Expect(decoder.DecodeAll(&decoded)).To(Succeed())
+			Expect(decoded).To(Equal([]Task{
+				{ID: "1", Timeout: 5 * time.Second},
+				{ID: "2", Owner: sql.NullString{String: "root", Valid: true}, Timeout: 90 * time.Second},
+			}))
+
+			Expect(csv.NewEncoder(buffer).EncodeAll(decoded)).To(Succeed())
+			Expect(buffer.String()).To(Equal("id,owner,timeout\n1,,5s\n2,root,1m30s\n"))
+		})
+	})
+
+	Context("when the header is disabled", func() {
+		It("encodes the records only", func() {
+			encoder := csv.NewEncoder(buffer)
+			encoder.Header = false
+
+			Expect(encoder.Encode(Sale{ID: "1"})).To(Succeed())
+			Expect(buffer.String()).To(Equal("1,,0,0,0,\n"))
+		})
+	})
+
+	Context("when the source is not a struct", func() {
+		It("returns an error", func() {
+			Expect(csv.NewEncoder(buffer).Encode(5)).To(MatchError("csv: source: int is not a struct"))
+		})
+	})
+
+	Describe("Header", func() {
+		type Record struct {
+			ID      string `csv:"id"`
+			Comment string `csv:"comment,index=3"`
+			Name    string
+		}
+
+		It("returns the column names at their positions", func() {
+			Expect(csv.Header(reflect.TypeOf(Record{}))).To(Equal([]string{"id", "", "", "comment", "Name"}))
+		})
+	})
+})
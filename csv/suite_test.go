@@ -0,0 +1,26 @@
+package csv_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCSV(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CSV Suite")
+}
+
+type Audit struct {
+	CreatedAt string `csv:"created_at"`
+}
+
+type Sale struct {
+	ID      string  `csv:"id"`
+	Name    string  `csv:"name"`
+	Count   int     `csv:"count"`
+	Enabled bool    `csv:"enabled"`
+	Score   float64 `csv:"score"`
+	Audit   *Audit  `csv:"~"`
+}
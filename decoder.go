@@ -1,8 +1,9 @@
 package inflate
 
 import (
-	"encoding/json"
+	"context"
 	"reflect"
+	"strings"
 )
 
 const (
@@ -22,14 +23,60 @@ const (
 	OptionSpaceDelimited = "space-delimited"
 	// OptionPipeDelimited is the pipe-delimited opt
 	OptionPipeDelimited = "pipe-delimited"
+	// OptionPrefix is the prefix opt
+	OptionPrefix = "prefix"
 )
 
 // Context is the context
 type Context struct {
-	Field  string
-	Type   reflect.Type
-	IsZero bool
-	Tag    *Tag
+	Field       string
+	Type        reflect.Type
+	IsZero      bool
+	Tag         *Tag
+	StructField reflect.StructField
+	// Parent is the struct that contains the field
+	Parent reflect.Value
+	// Path contains the Go names of the field and its enclosing fields
+	Path []string
+	// TagPath contains the tag names of the field and its enclosing fields
+	// except the inlined ones
+	TagPath []string
+	// Index is the index sequence for reflect.Value.FieldByIndex
+	Index []int
+	// Match defines how the providers match the tag name with their keys
+	Match NameMatch
+}
+
+// next returns the context of a field of the parent struct
+func (ctx *Context) next(parent reflect.Value, field *Field) *Context {
+	definition := field.StructField(parent.Type())
+
+	next := &Context{
+		Field:       field.Name,
+		Tag:         field.Tag,
+		IsZero:      field.Value.IsZero(),
+		StructField: definition,
+		Parent:      parent,
+		Path:        append(append([]string{}, ctx.Path...), field.Name),
+		TagPath:     append([]string{}, ctx.TagPath...),
+		Index:       append(append([]int{}, ctx.Index...), definition.Index...),
+		Match:       ctx.Match,
+	}
+
+	if field.Tag.Name != "~" {
+		next.TagPath = append(next.TagPath, field.Tag.Name)
+	}
+
+	return next
+}
+
+// name returns the tag path joined with dots
+func (ctx *Context) name() string {
+	if len(ctx.TagPath) == 0 {
+		return ctx.Tag.Name
+	}
+
+	return strings.Join(ctx.TagPath, ".")
 }
 
 //go:generate counterfeiter -fake-name ValueProvider -o ./fake/value_provider.go . ValueProvider
@@ -39,6 +86,18 @@ type ValueProvider interface {
 	Value(ctx *Context) (interface{}, error)
 }
 
+// KeyJoiner is implemented by the providers that join the names of the
+// nested struct fields with the prefix option in their own way
+type KeyJoiner interface {
+	JoinKey(names ...string) string
+}
+
+// ContextValueProvider provides a value and honours the cancellation and the
+// deadline of the context
+type ContextValueProvider interface {
+	ValueContext(ctx context.Context, field *Context) (interface{}, error)
+}
+
 //go:generate counterfeiter -fake-name ValueConverter -o ./fake/value_converter.go . ValueConverter
 
 // ValueConverter converts source to target
@@ -46,15 +105,63 @@ type ValueConverter interface {
 	Convert(source, target interface{}) error
 }
 
+// BeforeFieldFunc is called with the value returned by the provider before it
+// is converted. It returns the value that is converted instead.
+type BeforeFieldFunc func(ctx *Context, value interface{}) (interface{}, error)
+
+// AfterFieldFunc is called with the converted value before it is set
+type AfterFieldFunc func(ctx *Context, value reflect.Value) error
+
+// DecodeFieldFunc converts the value returned by the provider to the target
+type DecodeFieldFunc func(ctx *Context, value interface{}, target reflect.Value) error
+
 // Decoder decodes the values from given source
 type Decoder struct {
 	TagName   string
 	Provider  ValueProvider
 	Converter ValueConverter
+	Validator ValueValidator
+	before    []BeforeFieldFunc
+	after     []AfterFieldFunc
+	decoders  map[string]DecodeFieldFunc
+
+	// Match defines how the providers match the tag names with their keys.
+	// The default keeps the matching of each provider. The converter uses it
+	// for the nested maps unless it has its own.
+	Match NameMatch
+}
+
+// BeforeField registers a hook that is called before each field is converted
+func (d *Decoder) BeforeField(fn BeforeFieldFunc) {
+	d.before = append(d.before, fn)
+}
+
+// AfterField registers a hook that is called after each field is converted
+// and validated
+func (d *Decoder) AfterField(fn AfterFieldFunc) {
+	d.after = append(d.after, fn)
+}
+
+// DecodeField registers a function that decodes the field with given tag name
+// instead of the converter. The fields of the prefixed structs are registered
+// by their tag path joined with dots (e.g. page.size). The bare tag name
+// applies to the fields without a function registered by their path.
+func (d *Decoder) DecodeField(name string, fn DecodeFieldFunc) {
+	if d.decoders == nil {
+		d.decoders = make(map[string]DecodeFieldFunc)
+	}
+
+	d.decoders[name] = fn
 }
 
 // Decode decodes the values to given target
 func (d *Decoder) Decode(value interface{}) error {
+	return d.DecodeContext(context.Background(), value)
+}
+
+// DecodeContext decodes the values to given target. The decoding stops when
+// the context is done.
+func (d *Decoder) DecodeContext(ctx context.Context, value interface{}) error {
 	target, err := check("target", value)
 	if err != nil {
 		return err
@@ -69,16 +176,34 @@ func (d *Decoder) Decode(value interface{}) error {
 		}
 	}
 
-	return d.decode(StructOf(d.TagName, target))
+	// the tag options are checked once per type
+	if checker, ok := d.Provider.(ValueChecker); ok {
+		if err := checkType(d.TagName, target.Type(), checker); err != nil {
+			return err
+		}
+	}
+
+	// the chains report the origins of this decode only when it finishes
+	ctx, report := withOriginReport(ctx)
+	defer report.publish()
+
+	return d.decode(ctx, StructOf(d.TagName, target), &Context{Match: d.Match})
 }
 
-func (d *Decoder) decode(ch *Struct) error {
+func (d *Decoder) decode(parent context.Context, ch *Struct, owner *Context) error {
 	for _, field := range ch.Fields() {
-		target := refer(field.Value)
+		if err := parent.Err(); err != nil {
+			return err
+		}
 
-		if field.Tag.Name == "~" {
+		var (
+			target = refer(field.Value)
+			ctx    = owner.next(ch.Value, field)
+		)
+
+		if field.Tag.Name == "~" || prefixed(ctx, target) {
 			if target.Kind() == reflect.Struct {
-				if err := d.decode(StructOf(d.TagName, target)); err != nil {
+				if err := d.decode(parent, field.inline(d.TagName, target), ctx); err != nil {
 					return err
 				}
 			}
@@ -90,22 +215,59 @@ func (d *Decoder) decode(ch *Struct) error {
 			continue
 		}
 
-		ctx := &Context{
-			Field:  field.Name,
-			Tag:    field.Tag,
-			Type:   target.Type(),
-			IsZero: field.Value.IsZero(),
+		ctx.Type = target.Type()
+
+		// the fields of the prefixed structs are read from namespaced keys
+		if len(ctx.TagPath) > 1 {
+			tag := *ctx.Tag
+			tag.Name = d.join(ctx.TagPath)
+			ctx.Tag = &tag
+		}
+
+		// the providers fetch the value of the optional's type
+		if setter, ok := optionalOf(target); ok {
+			ctx.Type = setter.optionalType()
 		}
 
-		value, err := d.Provider.Value(ctx)
+		value, err := valueContext(parent, d.Provider, ctx)
 		if err != nil {
 			return err
 		}
 
-		source := elem(reflect.ValueOf(value))
+		for _, fn := range d.before {
+			if value, err = fn(ctx, value); err != nil {
+				return err
+			}
+		}
 
-		if err := d.Converter.Convert(source, target); err != nil {
-			return err
+		if fn, ok := d.decodeField(ctx, field); ok {
+			if err := fn(ctx, value, target); err != nil {
+				return err
+			}
+		} else {
+			source := elem(reflect.ValueOf(value))
+
+			if err := d.converter().Convert(source, target); err != nil {
+				return err
+			}
+		}
+
+		if d.Validator != nil {
+			var converted interface{}
+
+			if value != nil {
+				converted = target.Interface()
+			}
+
+			if err := d.Validator.Validate(ctx, converted); err != nil {
+				return err
+			}
+		}
+
+		for _, fn := range d.after {
+			if err := fn(ctx, target); err != nil {
+				return err
+			}
 		}
 
 		if err := set(field.Value, target); err != nil {
@@ -116,60 +278,76 @@ func (d *Decoder) decode(ch *Struct) error {
 	return nil
 }
 
-// Set sets the value
-func Set(target, source interface{}) error {
-	converter := &Converter{
-		TagName: "field",
+// decodeField returns the function registered for the field's tag path or
+// its tag name
+func (d *Decoder) decodeField(ctx *Context, field *Field) (DecodeFieldFunc, bool) {
+	if fn, ok := d.decoders[ctx.name()]; ok {
+		return fn, true
 	}
 
-	return converter.Convert(source, target)
+	fn, ok := d.decoders[field.Tag.Name]
+	return fn, ok
 }
 
-// SetDefault set the default values
-func SetDefault(target interface{}) error {
-	decoder := &Decoder{
-		TagName: "default",
-		Converter: &Converter{
-			TagName: "default",
-		},
-		Provider: &DefaultProvider{},
+// converter returns the converter that matches the keys of the nested maps
+// with the decoder's strategy unless the converter has its own
+func (d *Decoder) converter() ValueConverter {
+	if converter, ok := d.Converter.(*Converter); ok && converter.Match == MatchDefault && d.Match != MatchDefault {
+		next := *converter
+		next.Match = d.Match
+		return &next
 	}
 
-	return decoder.Decode(target)
+	return d.Converter
 }
 
-// DefaultProvider returns the default for given field
-type DefaultProvider struct{}
-
-// Value returns the default value if specified
-func (p *DefaultProvider) Value(ctx *Context) (interface{}, error) {
-	if !ctx.IsZero {
-		return nil, nil
+func (d *Decoder) join(names []string) string {
+	if joiner, ok := d.Provider.(KeyJoiner); ok {
+		return joiner.JoinKey(names...)
 	}
 
-	var (
-		value = ctx.Tag.Name
-		kind  = ctx.Type.Kind()
-	)
+	return strings.Join(names, ".")
+}
+
+// prefixed returns true if the field is a struct with prefix option. The
+// prefix replaces the field's tag name in the tag path if it has a value.
+func prefixed(ctx *Context, target reflect.Value) bool {
+	prefix, ok := ctx.Tag.Lookup(OptionPrefix)
 
-	if value == "" {
-		return nil, nil
+	if !ok && !ctx.Tag.HasOption(OptionPrefix) {
+		return false
 	}
 
-	if convertable(ctx.Type) {
-		return value, nil
+	if _, ok := optionalOf(target); ok || kind(target) != reflect.Struct || convertable(target.Type()) {
+		return false
 	}
 
-	if kind == reflect.Ptr {
-		kind = ctx.Type.Elem().Kind()
+	if prefix != "" {
+		ctx.TagPath[len(ctx.TagPath)-1] = prefix
 	}
 
-	switch kind {
-	case reflect.Map, reflect.Struct:
-		return json.RawMessage(value), nil
-	case reflect.Array, reflect.Slice:
-		return json.RawMessage(value), nil
-	default:
-		return value, nil
+	return true
+}
+
+// Clone copies the source to the target by allocating new maps, slices and
+// pointers instead of sharing them
+func Clone(target, source interface{}) error {
+	converter := &Converter{
+		TagName:  "field,json",
+		DeepCopy: true,
 	}
+
+	return converter.Convert(source, target)
+}
+
+// Set sets the value. The fields are matched by their field tag or their json
+// tag if it is absent. The map keys are matched regardless of their case and
+// their snake_case, kebab-case or CamelCase form.
+func Set(target, source interface{}) error {
+	converter := &Converter{
+		TagName: "field,json",
+		Match:   MatchNormalize,
+	}
+
+	return converter.Convert(source, target)
 }
@@ -1,8 +1,11 @@
 package inflate_test
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
 
 	"github.com/phogolabs/inflate"
 	"github.com/phogolabs/inflate/fake"
@@ -85,6 +88,22 @@ var _ = Describe("Decoder", func() {
 			Expect(account.User.Name).To(Equal("Jack"))
 		})
 
+		It("provides the path of the field", func() {
+			provider.ValueReturns("Jack", nil)
+
+			account := &Account{}
+			Expect(decoder.Decode(account)).To(Succeed())
+			Expect(provider.ValueCallCount()).To(Equal(1))
+
+			ctx := provider.ValueArgsForCall(0)
+			Expect(ctx.Field).To(Equal("Name"))
+			Expect(ctx.Path).To(Equal([]string{"User", "Name"}))
+			Expect(ctx.TagPath).To(Equal([]string{"name"}))
+			Expect(ctx.Index).To(Equal([]int{0, 0}))
+			Expect(ctx.StructField.Tag.Get("fake")).To(Equal("name"))
+			Expect(ctx.Parent.Type()).To(Equal(reflect.TypeOf(User{})))
+		})
+
 		Context("when the provider fails", func() {
 			BeforeEach(func() {
 				provider.ValueReturns(nil, fmt.Errorf("oh no"))
@@ -109,6 +128,132 @@ var _ = Describe("Decoder", func() {
 			})
 		})
 	})
+
+	Context("when the context is canceled", func() {
+		It("returns an error", func() {
+			parent, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			user := &User{}
+			Expect(decoder.DecodeContext(parent, user)).To(MatchError(context.Canceled))
+			Expect(provider.ValueCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the provider is context aware", func() {
+		type key struct{}
+
+		It("passes the context to the provider", func() {
+			decoder.Provider = &inflate.TagProvider{
+				TagName: "fake",
+				Provider: &inflate.ChainProvider{
+					Providers: []inflate.ValueProvider{
+						&contextProvider{Key: key{}},
+					},
+				},
+			}
+
+			user := &User{}
+			Expect(decoder.DecodeContext(context.WithValue(context.Background(), key{}, "Jack"), user)).To(Succeed())
+			Expect(user.Name).To(Equal("Jack"))
+		})
+	})
+
+	Context("when there are field hooks", func() {
+		BeforeEach(func() {
+			provider.ValueReturns(" Jack ", nil)
+		})
+
+		It("calls the hooks successfully", func() {
+			decoder.BeforeField(func(ctx *inflate.Context, value interface{}) (interface{}, error) {
+				Expect(ctx.Field).To(Equal("Name"))
+				Expect(ctx.Tag.Name).To(Equal("name"))
+				return strings.TrimSpace(value.(string)), nil
+			})
+
+			decoder.AfterField(func(ctx *inflate.Context, value reflect.Value) error {
+				value.SetString(strings.ToUpper(value.String()))
+				return nil
+			})
+
+			user := &User{}
+			Expect(decoder.Decode(user)).To(Succeed())
+			Expect(user.Name).To(Equal("JACK"))
+		})
+
+		Context("when the before hook fails", func() {
+			It("returns an error", func() {
+				decoder.BeforeField(func(ctx *inflate.Context, value interface{}) (interface{}, error) {
+					return nil, fmt.Errorf("oh no")
+				})
+
+				user := &User{}
+				Expect(decoder.Decode(user)).To(MatchError("oh no"))
+				Expect(converter.ConvertCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the after hook fails", func() {
+			It("returns an error", func() {
+				decoder.AfterField(func(ctx *inflate.Context, value reflect.Value) error {
+					return fmt.Errorf("field: '%v' rejected", ctx.Tag.Name)
+				})
+
+				user := &User{}
+				Expect(decoder.Decode(user)).To(MatchError("field: 'name' rejected"))
+				Expect(user.Name).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when there is a field decode function", func() {
+		It("decodes the field with the function", func() {
+			provider.ValueReturns("jack", nil)
+
+			decoder.DecodeField("name", func(ctx *inflate.Context, value interface{}, target reflect.Value) error {
+				target.SetString(strings.ToUpper(value.(string)))
+				return nil
+			})
+
+			user := &User{}
+			Expect(decoder.Decode(user)).To(Succeed())
+			Expect(user.Name).To(Equal("JACK"))
+			Expect(converter.ConvertCallCount()).To(BeZero())
+		})
+
+		Context("when the prefixed structs have fields with the same name", func() {
+			type Page struct {
+				Size int `query:"size"`
+			}
+
+			type Request struct {
+				Posts    Page `query:"posts,prefix"`
+				Comments Page `query:"comments,prefix"`
+				Size     int  `query:"size"`
+			}
+
+			It("decodes the fields with the function of their tag path", func() {
+				query := url.Values{"posts.size": {"1"}, "comments.size": {"2"}, "size": {"3"}}
+				decoder := inflate.NewQueryDecoder(query)
+
+				decoder.DecodeField("posts.size", func(ctx *inflate.Context, value interface{}, target reflect.Value) error {
+					target.SetInt(10)
+					return nil
+				})
+
+				decoder.DecodeField("size", func(ctx *inflate.Context, value interface{}, target reflect.Value) error {
+					target.SetInt(30)
+					return nil
+				})
+
+				request := &Request{}
+				Expect(decoder.Decode(request)).To(Succeed())
+				Expect(request.Posts.Size).To(Equal(10))
+				Expect(request.Comments.Size).To(Equal(30))
+				Expect(request.Size).To(Equal(30))
+			})
+		})
+	})
 })
 
 var _ = Describe("SetDefault", func() {
@@ -160,4 +305,53 @@ var _ = Describe("Set", func() {
 		Expect(inflate.Set(target, source)).To(Succeed())
 		Expect(target.OrderID).To(Equal(source.ID))
 	})
+
+	Context("when the fields have json tags only", func() {
+		type Invoice struct {
+			OrderID string  `json:"order_id"`
+			Total   float64 `json:"total,string"`
+			Note    string  `json:"note,omitempty"`
+			Secret  string  `json:"-"`
+		}
+
+		It("matches the fields by their json names", func() {
+			source := &Order{ID: "0000123"}
+			target := &Invoice{}
+
+			Expect(inflate.Set(target, source)).To(Succeed())
+			Expect(target.OrderID).To(Equal(source.ID))
+		})
+
+		It("reads the string option values", func() {
+			source := map[string]interface{}{"total": "10.5", "Secret": "key"}
+			target := &Invoice{}
+
+			Expect(inflate.Set(target, &source)).To(Succeed())
+			Expect(target.Total).To(Equal(10.5))
+			Expect(target.Secret).To(BeEmpty())
+		})
+
+		It("writes the string option values", func() {
+			source := &Invoice{OrderID: "1", Total: 10.5, Secret: "key"}
+			target := map[string]interface{}{}
+
+			Expect(inflate.Set(&target, source)).To(Succeed())
+			Expect(target).To(Equal(map[string]interface{}{
+				"order_id": "1",
+				"total":    "10.5",
+			}))
+		})
+	})
 })
+
+type contextProvider struct {
+	Key interface{}
+}
+
+func (p *contextProvider) Value(ctx *inflate.Context) (interface{}, error) {
+	return nil, nil
+}
+
+func (p *contextProvider) ValueContext(parent context.Context, ctx *inflate.Context) (interface{}, error) {
+	return parent.Value(p.Key), nil
+}
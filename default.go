@@ -0,0 +1,395 @@
+package inflate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultGenerator generates a default value for given field. The arguments
+// of the generator are available as ctx.Tag.Options.
+type DefaultGenerator func(ctx *Context) (interface{}, error)
+
+var generators = map[string]DefaultGenerator{
+	"now":  generateNow,
+	"uuid": generateUUID,
+	"env":  generateEnv,
+}
+
+// Defaulter is implemented by the types that set their own default values
+type Defaulter interface {
+	SetDefaults()
+}
+
+// ErrDefaulter is implemented by the types that set their own default values
+// and might fail
+type ErrDefaulter interface {
+	Defaults() error
+}
+
+// DefaultMethod defines when the Defaulter methods are called
+type DefaultMethod int
+
+const (
+	// DefaultMethodAfter calls the methods after the tag defaults are set
+	DefaultMethodAfter DefaultMethod = iota
+	// DefaultMethodBefore calls the methods before the tag defaults are set
+	DefaultMethodBefore
+	// DefaultMethodBoth calls the methods before and after the tag defaults are set
+	DefaultMethodBoth
+	// DefaultMethodNone does not call the methods
+	DefaultMethodNone
+)
+
+// NewDefaultDecoder creates a default decoder
+func NewDefaultDecoder(provider *DefaultProvider) *DefaultDecoder {
+	return &DefaultDecoder{
+		Converter: &Converter{
+			TagName: "default",
+		},
+		Provider: provider,
+	}
+}
+
+// SetDefault set the default values
+func SetDefault(target interface{}) error {
+	return NewDefaultDecoder(&DefaultProvider{}).Decode(target)
+}
+
+// DefaultDecoder sets the default values of a struct, its nested structs and
+// the elements of its slices, arrays and maps
+type DefaultDecoder struct {
+	Provider  *DefaultProvider
+	Converter ValueConverter
+	Method    DefaultMethod
+
+	// AllocatePointers allocates the nil pointers to structs and sets the
+	// defaults of their fields. Otherwise they are allocated only if their
+	// field has a default value.
+	AllocatePointers bool
+}
+
+// Decode decodes the values to given target
+func (d *DefaultDecoder) Decode(value interface{}) error {
+	target, err := check("target", value)
+	if err != nil {
+		return err
+	}
+
+	if target.Kind() == reflect.Ptr && target.IsZero() {
+		target.Set(reflect.New(target.Type().Elem()))
+	}
+
+	walker := &defaultWalker{
+		decoder: &Decoder{
+			TagName:   "default",
+			Converter: d.Converter,
+			Provider:  d.Provider,
+		},
+		method:   d.Method,
+		allocate: d.AllocatePointers,
+		visited:  make(map[uintptr]bool),
+		stack:    make(map[reflect.Type]int),
+	}
+
+	return walker.walk(target)
+}
+
+type defaultWalker struct {
+	decoder  *Decoder
+	method   DefaultMethod
+	allocate bool
+	visited  map[uintptr]bool
+	stack    map[reflect.Type]int
+}
+
+func (w *defaultWalker) walk(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() || w.visited[value.Pointer()] {
+			return nil
+		}
+
+		w.visited[value.Pointer()] = true
+		return w.walk(value.Elem())
+	case reflect.Interface:
+		if item := value.Elem(); item.Kind() == reflect.Ptr {
+			return w.walk(item)
+		}
+	case reflect.Struct:
+		return w.walkStruct(value)
+	case reflect.Array, reflect.Slice:
+		for index := 0; index < value.Len(); index++ {
+			if err := w.walk(value.Index(index)); err != nil {
+				return rerrorf(fmt.Sprintf("%v", index), err)
+			}
+		}
+	case reflect.Map:
+		return w.walkMap(value)
+	}
+
+	return nil
+}
+
+func (w *defaultWalker) walkStruct(value reflect.Value) error {
+	if !value.CanAddr() || convertable(value.Type()) {
+		return nil
+	}
+
+	w.stack[value.Type()]++
+	defer func() { w.stack[value.Type()]-- }()
+
+	var nils []int
+
+	for index := 0; index < value.NumField(); index++ {
+		if field := value.Field(index); field.Kind() == reflect.Ptr && field.IsNil() {
+			nils = append(nils, index)
+		}
+	}
+
+	if w.method == DefaultMethodBefore || w.method == DefaultMethodBoth {
+		if err := w.call(value); err != nil {
+			return err
+		}
+	}
+
+	if err := w.decoder.decode(context.Background(), StructOf(w.decoder.TagName, value), &Context{}); err != nil {
+		return err
+	}
+
+	// the decoder allocates every nil pointer
+	for _, index := range nils {
+		if field := value.Type().Field(index); !w.allocated(field) {
+			value.Field(index).Set(reflect.Zero(field.Type))
+		}
+	}
+
+	if w.method == DefaultMethodAfter || w.method == DefaultMethodBoth {
+		if err := w.call(value); err != nil {
+			return err
+		}
+	}
+
+	for index := 0; index < value.NumField(); index++ {
+		var (
+			field = value.Type().Field(index)
+			item  = value.Field(index)
+		)
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if err := w.walk(item); err != nil {
+			return rerrorf(field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// allocated returns true if the nil pointer allocated by the decoder is kept
+func (w *defaultWalker) allocated(field reflect.StructField) bool {
+	item := field.Type.Elem()
+
+	// the pointers to an enclosing type would be allocated again and again
+	// for self-referential types
+	if w.stack[item] > 0 {
+		return false
+	}
+
+	if _, ok := field.Tag.Lookup(w.decoder.TagName); ok || field.Anonymous {
+		return true
+	}
+
+	if item.Kind() != reflect.Struct || convertable(item) || isOptional(item) {
+		return true
+	}
+
+	return w.allocate
+}
+
+func (w *defaultWalker) call(value reflect.Value) error {
+	switch defaulter := value.Addr().Interface().(type) {
+	case ErrDefaulter:
+		return defaulter.Defaults()
+	case Defaulter:
+		defaulter.SetDefaults()
+	}
+
+	return nil
+}
+
+func (w *defaultWalker) walkMap(value reflect.Value) error {
+	if value.IsNil() {
+		return nil
+	}
+
+	switch value.Type().Elem().Kind() {
+	case reflect.Struct, reflect.Array:
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		iter := value.MapRange()
+
+		for iter.Next() {
+			if err := w.walk(iter.Value()); err != nil {
+				return rerrorf(fmt.Sprintf("%v", iter.Key().Interface()), err)
+			}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+
+	// the map values are not addressable
+	iter := value.MapRange()
+
+	for iter.Next() {
+		item := reflect.New(value.Type().Elem()).Elem()
+		item.Set(iter.Value())
+
+		if err := w.walk(item); err != nil {
+			return rerrorf(fmt.Sprintf("%v", iter.Key().Interface()), err)
+		}
+
+		value.SetMapIndex(iter.Key(), item)
+	}
+
+	return nil
+}
+
+// DefaultProvider returns the default for given field. The default values
+// that start with $ (e.g. $now, $uuid or $env:HOME) are generated by the
+// generator registered with that name.
+type DefaultProvider struct {
+	generators map[string]DefaultGenerator
+}
+
+// Register registers a generator that can be referenced as $name
+func (p *DefaultProvider) Register(name string, fn DefaultGenerator) {
+	if p.generators == nil {
+		p.generators = make(map[string]DefaultGenerator)
+	}
+
+	p.generators[name] = fn
+}
+
+// Value returns the default value if specified
+func (p *DefaultProvider) Value(ctx *Context) (interface{}, error) {
+	if !ctx.IsZero {
+		return nil, nil
+	}
+
+	var (
+		value = ctx.Tag.Name
+		kind  = ctx.Type.Kind()
+	)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(value, "$") {
+		if value, ok, err := p.generate(ctx); ok {
+			return value, err
+		}
+	}
+
+	if convertable(ctx.Type) {
+		return value, nil
+	}
+
+	if kind == reflect.Ptr {
+		kind = ctx.Type.Elem().Kind()
+	}
+
+	switch kind {
+	case reflect.Map, reflect.Struct:
+		return json.RawMessage(value), nil
+	case reflect.Array, reflect.Slice:
+		return json.RawMessage(value), nil
+	default:
+		return value, nil
+	}
+}
+
+func (p *DefaultProvider) generate(ctx *Context) (interface{}, bool, error) {
+	var (
+		parts = strings.Split(strings.TrimPrefix(ctx.Tag.Name, "$"), ":")
+		name  = parts[0]
+	)
+
+	fn, ok := p.generators[name]
+
+	if !ok {
+		// the unknown generators are treated as literal values
+		if fn, ok = generators[name]; !ok {
+			return nil, false, nil
+		}
+	}
+
+	next := *ctx
+	next.Tag = &Tag{
+		Key:     ctx.Tag.Key,
+		Name:    name,
+		Options: parts[1:],
+	}
+
+	value, err := fn(&next)
+	if err != nil {
+		field := ctx.Field
+
+		// the tag name of the default tag is the value
+		if len(ctx.Path) > 0 {
+			field = strings.Join(ctx.Path, ".")
+		}
+
+		return nil, true, fmt.Errorf("default: field: '%v' generator: [%v] failed: %w", field, name, err)
+	}
+
+	return value, true, nil
+}
+
+func generateNow(ctx *Context) (interface{}, error) {
+	now := time.Now()
+
+	switch kind(reflect.New(ctx.Type).Elem()) {
+	case reflect.Int:
+		return now.Unix(), nil
+	case reflect.String:
+		return now.Format(time.RFC3339), nil
+	default:
+		return now, nil
+	}
+}
+
+func generateUUID(ctx *Context) (interface{}, error) {
+	data := make([]byte, 16)
+
+	if _, err := rand.Read(data); err != nil {
+		return nil, err
+	}
+
+	// version 4 and variant RFC 4122
+	data[6] = (data[6] & 0x0f) | 0x40
+	data[8] = (data[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:]), nil
+}
+
+func generateEnv(ctx *Context) (interface{}, error) {
+	if len(ctx.Tag.Options) == 0 {
+		return nil, fmt.Errorf("variable name not provided")
+	}
+
+	if value, ok := os.LookupEnv(ctx.Tag.Options[0]); ok {
+		return value, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,307 @@
+package inflate_test
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DefaultProvider", func() {
+	var provider *inflate.DefaultProvider
+
+	BeforeEach(func() {
+		provider = &inflate.DefaultProvider{}
+	})
+
+	Context("when the default value is generated", func() {
+		type Event struct {
+			ID        string    `default:"$uuid"`
+			CreatedAt time.Time `default:"$now"`
+			Timestamp int64     `default:"$now"`
+			Home      string    `default:"$env:INFLATE_HOME"`
+			Price     string    `default:"$5"`
+		}
+
+		BeforeEach(func() {
+			Expect(os.Setenv("INFLATE_HOME", "/home/inflate")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "INFLATE_HOME")
+		})
+
+		It("sets the default values successfully", func() {
+			event := &Event{}
+
+			Expect(inflate.NewDefaultDecoder(provider).Decode(event)).To(Succeed())
+			Expect(event.ID).To(MatchRegexp("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"))
+			Expect(event.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+			Expect(event.Timestamp).To(BeNumerically("~", time.Now().Unix(), 1))
+			Expect(event.Home).To(Equal("/home/inflate"))
+			Expect(event.Price).To(Equal("$5"))
+		})
+
+		Context("when the environment variable is not set", func() {
+			BeforeEach(func() {
+				Expect(os.Unsetenv("INFLATE_HOME")).To(Succeed())
+			})
+
+			It("does not set the value", func() {
+				event := &Event{}
+
+				Expect(inflate.NewDefaultDecoder(provider).Decode(event)).To(Succeed())
+				Expect(event.Home).To(BeEmpty())
+			})
+		})
+
+		Context("when the generator is registered", func() {
+			type Request struct {
+				ID    string `default:"$id:req"`
+				Count int    `default:"$id"`
+			}
+
+			BeforeEach(func() {
+				provider.Register("id", func(ctx *inflate.Context) (interface{}, error) {
+					if ctx.Type.Kind() == reflect.Int {
+						return 42, nil
+					}
+
+					return fmt.Sprintf("%v-42", ctx.Tag.Options[0]), nil
+				})
+			})
+
+			It("sets the default values successfully", func() {
+				request := &Request{}
+
+				Expect(inflate.NewDefaultDecoder(provider).Decode(request)).To(Succeed())
+				Expect(request.ID).To(Equal("req-42"))
+				Expect(request.Count).To(Equal(42))
+			})
+
+			Context("when the generator fails", func() {
+				BeforeEach(func() {
+					provider.Register("id", func(ctx *inflate.Context) (interface{}, error) {
+						return nil, fmt.Errorf("oh no")
+					})
+				})
+
+				It("returns an error", func() {
+					request := &Request{}
+
+					Expect(inflate.NewDefaultDecoder(provider).Decode(request)).To(MatchError("default: field: 'ID' generator: [id] failed: oh no"))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("DefaultDecoder", func() {
+	type Item struct {
+		Name  string `default:"unknown"`
+		Count int    `default:"1"`
+	}
+
+	type Node struct {
+		Name string `default:"node"`
+		Next *Node
+	}
+
+	type Order struct {
+		Items   []Item
+		Array   [1]Item
+		Refs    []*Item
+		Lookup  map[string]Item
+		Pointer map[string]*Item
+		Item    *Item
+		Node    *Node
+	}
+
+	var decoder *inflate.DefaultDecoder
+
+	BeforeEach(func() {
+		decoder = inflate.NewDefaultDecoder(&inflate.DefaultProvider{})
+	})
+
+	It("sets the defaults of the elements", func() {
+		order := &Order{
+			Items:   []Item{{Name: "phone"}, {}},
+			Refs:    []*Item{{Count: 2}, nil},
+			Lookup:  map[string]Item{"phone": {}},
+			Pointer: map[string]*Item{"phone": {Name: "phone"}},
+		}
+
+		Expect(decoder.Decode(order)).To(Succeed())
+		Expect(order.Items).To(Equal([]Item{{Name: "phone", Count: 1}, {Name: "unknown", Count: 1}}))
+		Expect(order.Array).To(Equal([1]Item{{Name: "unknown", Count: 1}}))
+		Expect(order.Refs[0]).To(Equal(&Item{Name: "unknown", Count: 2}))
+		Expect(order.Refs[1]).To(BeNil())
+		Expect(order.Lookup).To(HaveKeyWithValue("phone", Item{Name: "unknown", Count: 1}))
+		Expect(order.Pointer).To(HaveKeyWithValue("phone", &Item{Name: "phone", Count: 1}))
+		Expect(order.Item).To(BeNil())
+		Expect(order.Node).To(BeNil())
+	})
+
+	Context("when the pointers are allocated", func() {
+		BeforeEach(func() {
+			decoder.AllocatePointers = true
+		})
+
+		It("sets the defaults of the allocated structs", func() {
+			order := &Order{}
+
+			Expect(decoder.Decode(order)).To(Succeed())
+			Expect(order.Item).To(Equal(&Item{Name: "unknown", Count: 1}))
+			Expect(order.Node).To(Equal(&Node{Name: "node"}))
+		})
+	})
+
+	Context("when the type is self-referential", func() {
+		It("sets the defaults successfully", func() {
+			node := &Node{Next: &Node{Next: &Node{}}}
+
+			Expect(decoder.Decode(node)).To(Succeed())
+			Expect(node.Name).To(Equal("node"))
+			Expect(node.Next.Name).To(Equal("node"))
+			Expect(node.Next.Next.Name).To(Equal("node"))
+			Expect(node.Next.Next.Next).To(BeNil())
+		})
+
+		Context("when the pointers are allocated", func() {
+			It("does not allocate the pointers to the enclosing type", func() {
+				node := &Node{}
+				decoder.AllocatePointers = true
+
+				Expect(decoder.Decode(node)).To(Succeed())
+				Expect(node).To(Equal(&Node{Name: "node"}))
+			})
+		})
+
+		Context("when the value has a cycle", func() {
+			It("sets the defaults successfully", func() {
+				node := &Node{}
+				node.Next = node
+
+				Expect(decoder.Decode(node)).To(Succeed())
+				Expect(node.Name).To(Equal("node"))
+			})
+		})
+	})
+
+	Context("when the element fails", func() {
+		type Invalid struct {
+			Count int `default:"one"`
+		}
+
+		type List struct {
+			Items []Invalid
+		}
+
+		It("returns an error", func() {
+			list := &List{Items: []Invalid{{}}}
+			Expect(decoder.Decode(list)).To(MatchError(`Items: 0: cannot convert string 'one' to int: strconv.ParseInt: parsing "one": invalid syntax`))
+		})
+	})
+})
+
+type Endpoint struct {
+	Scheme string `default:"http"`
+	Port   int
+	Calls  int
+}
+
+func (e *Endpoint) SetDefaults() {
+	e.Calls++
+
+	if e.Port != 0 {
+		return
+	}
+
+	switch e.Scheme {
+	case "https":
+		e.Port = 443
+	case "http":
+		e.Port = 80
+	}
+}
+
+type Service struct {
+	Name      string
+	Endpoints []Endpoint
+}
+
+func (s *Service) Defaults() error {
+	if s.Name == "" {
+		return fmt.Errorf("name not provided")
+	}
+
+	return nil
+}
+
+var _ = Describe("Defaulter", func() {
+	var decoder *inflate.DefaultDecoder
+
+	BeforeEach(func() {
+		decoder = inflate.NewDefaultDecoder(&inflate.DefaultProvider{})
+	})
+
+	It("calls the methods after the tag defaults are set", func() {
+		service := &Service{
+			Name:      "api",
+			Endpoints: []Endpoint{{Scheme: "https"}, {}},
+		}
+
+		Expect(decoder.Decode(service)).To(Succeed())
+		Expect(service.Endpoints).To(Equal([]Endpoint{
+			{Scheme: "https", Port: 443, Calls: 1},
+			{Scheme: "http", Port: 80, Calls: 1},
+		}))
+	})
+
+	Context("when the methods are called before the tag defaults are set", func() {
+		BeforeEach(func() {
+			decoder.Method = inflate.DefaultMethodBefore
+		})
+
+		It("calls the methods successfully", func() {
+			endpoint := &Endpoint{}
+
+			Expect(decoder.Decode(endpoint)).To(Succeed())
+			Expect(endpoint).To(Equal(&Endpoint{Scheme: "http", Calls: 1}))
+		})
+	})
+
+	Context("when the methods are called before and after the tag defaults are set", func() {
+		BeforeEach(func() {
+			decoder.Method = inflate.DefaultMethodBoth
+		})
+
+		It("calls the methods successfully", func() {
+			endpoint := &Endpoint{}
+
+			Expect(decoder.Decode(endpoint)).To(Succeed())
+			Expect(endpoint).To(Equal(&Endpoint{Scheme: "http", Port: 80, Calls: 2}))
+		})
+	})
+
+	Context("when the methods are not called", func() {
+		BeforeEach(func() {
+			decoder.Method = inflate.DefaultMethodNone
+		})
+
+		It("sets the tag defaults only", func() {
+			endpoint := &Endpoint{}
+
+			Expect(decoder.Decode(endpoint)).To(Succeed())
+			Expect(endpoint).To(Equal(&Endpoint{Scheme: "http"}))
+		})
+	})
+
+	Context("when the method fails", func() {
+		It("returns an error", func() {
+			Expect(decoder.Decode(&Service{})).To(MatchError("name not provided"))
+		})
+	})
+})
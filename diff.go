@@ -0,0 +1,310 @@
+package inflate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeOp is the kind of change
+type ChangeOp string
+
+const (
+	// ChangeAdd is reported when the value is added to a map, a slice or an
+	// omitempty field
+	ChangeAdd ChangeOp = "add"
+	// ChangeRemove is reported when the value is removed from a map, a slice or
+	// an omitempty field
+	ChangeRemove ChangeOp = "remove"
+	// ChangeReplace is reported when the value is changed
+	ChangeReplace ChangeOp = "replace"
+)
+
+// Change represents a changed value. The path is a JSON pointer (RFC 6901)
+// built from the field tag names, the map keys and the slice indexes.
+type Change struct {
+	Op   ChangeOp
+	Path string
+	From interface{}
+	To   interface{}
+}
+
+// Diff returns the changes between the source and the target. The fields are
+// matched by their field tag or their json tag if it is absent.
+func Diff(source, target interface{}) []Change {
+	differ := &differ{
+		TagName: "field,json",
+	}
+
+	differ.diff("", reflect.ValueOf(source), reflect.ValueOf(target))
+	return differ.changes
+}
+
+// JSONPatch returns the changes as JSON Patch (RFC 6902) document
+func JSONPatch(changes []Change) (json.RawMessage, error) {
+	operations := make([]map[string]interface{}, len(changes))
+
+	for index, change := range changes {
+		operation := map[string]interface{}{
+			"op":   change.Op,
+			"path": change.Path,
+		}
+
+		if change.Op != ChangeRemove {
+			operation["value"] = change.To
+		}
+
+		operations[index] = operation
+	}
+
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return nil, fmt.Errorf("json patch: %w", err)
+	}
+
+	return json.RawMessage(data), nil
+}
+
+type differ struct {
+	TagName string
+	changes []Change
+}
+
+func (d *differ) diff(path string, source, target reflect.Value) {
+	source = d.deref(source)
+	target = d.deref(target)
+
+	switch {
+	case !source.IsValid() && !target.IsValid():
+		return
+	case !source.IsValid() || !target.IsValid() || source.Type() != target.Type():
+		d.replace(path, source, target)
+		return
+	}
+
+	switch source.Kind() {
+	case reflect.Struct:
+		if isOptional(source.Type()) || convertable(source.Type()) {
+			break
+		}
+
+		d.diffStruct(path, source, target)
+		return
+	case reflect.Map:
+		if source.IsNil() != target.IsNil() {
+			break
+		}
+
+		d.diffMap(path, source, target)
+		return
+	case reflect.Slice:
+		if source.IsNil() != target.IsNil() {
+			break
+		}
+
+		d.diffArray(path, source, target)
+		return
+	case reflect.Array:
+		d.diffArray(path, source, target)
+		return
+	}
+
+	if !d.equal(source, target) {
+		d.replace(path, source, target)
+	}
+}
+
+// equal compares the values with their Equal method if they have one (e.g.
+// time.Time ignores the monotonic clock reading)
+func (d *differ) equal(source, target reflect.Value) bool {
+	if getter, ok := optionalValueOf(source); ok {
+		other, _ := optionalValueOf(target)
+
+		var (
+			from, fromPresent, fromValid = getter.optional()
+			to, toPresent, toValid       = other.optional()
+		)
+
+		if fromPresent != toPresent || fromValid != toValid {
+			return false
+		}
+
+		return d.equal(from, to)
+	}
+
+	if method := source.MethodByName("Equal"); method.IsValid() {
+		kind := method.Type()
+
+		if kind.NumIn() == 1 && kind.NumOut() == 1 && kind.In(0) == target.Type() && kind.Out(0).Kind() == reflect.Bool {
+			return method.Call([]reflect.Value{target})[0].Bool()
+		}
+	}
+
+	return reflect.DeepEqual(source.Interface(), target.Interface())
+}
+
+func (d *differ) diffStruct(path string, source, target reflect.Value) {
+	var (
+		sources = StructOf(d.TagName, source).Fields()
+		targets = StructOf(d.TagName, target).Fields()
+	)
+
+	// the promoted fields of a nil embedded pointer are not listed so the
+	// structs are compared by their values
+	if !d.same(sources, targets) {
+		d.diffMap(path, StructOf(d.TagName, source).Map().Value, StructOf(d.TagName, target).Map().Value)
+		return
+	}
+
+	for index, field := range sources {
+		var (
+			next  = path
+			other = targets[index]
+		)
+
+		// the inlined fields share the path of the enclosing struct
+		if field.Tag.Name != "~" {
+			next = d.join(path, field.Tag.Name)
+		}
+
+		// the empty values are omitted from the documents so they are added
+		// or removed instead of replaced
+		if field.Tag.HasOption("omitempty") && field.IsZero() != other.IsZero() {
+			if field.IsZero() {
+				d.add(ChangeAdd, next, nil, d.valueOf(other.Value))
+			} else {
+				d.add(ChangeRemove, next, d.valueOf(field.Value), nil)
+			}
+
+			continue
+		}
+
+		d.diff(next, field.Value, other.Value)
+	}
+}
+
+func (d *differ) same(sources, targets []*Field) bool {
+	if len(sources) != len(targets) {
+		return false
+	}
+
+	for index, field := range sources {
+		if field.Name != targets[index].Name || field.Tag.Name != targets[index].Tag.Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *differ) diffMap(path string, source, target reflect.Value) {
+	keys := make(map[string]reflect.Value)
+
+	for _, key := range source.MapKeys() {
+		keys[fmt.Sprintf("%v", key.Interface())] = key
+	}
+
+	for _, key := range target.MapKeys() {
+		keys[fmt.Sprintf("%v", key.Interface())] = key
+	}
+
+	names := make([]string, 0, len(keys))
+
+	for name := range keys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		var (
+			next = d.join(path, name)
+			from = source.MapIndex(keys[name])
+			to   = target.MapIndex(keys[name])
+		)
+
+		switch {
+		case !from.IsValid():
+			d.add(ChangeAdd, next, nil, d.valueOf(to))
+		case !to.IsValid():
+			d.add(ChangeRemove, next, d.valueOf(from), nil)
+		default:
+			d.diff(next, from, to)
+		}
+	}
+}
+
+func (d *differ) diffArray(path string, source, target reflect.Value) {
+	count := source.Len()
+
+	if target.Len() < count {
+		count = target.Len()
+	}
+
+	for index := 0; index < count; index++ {
+		d.diff(d.join(path, fmt.Sprintf("%v", index)), source.Index(index), target.Index(index))
+	}
+
+	for index := count; index < target.Len(); index++ {
+		d.add(ChangeAdd, d.join(path, fmt.Sprintf("%v", index)), nil, d.valueOf(target.Index(index)))
+	}
+
+	// the items are removed from the end so the indexes of the patch stay valid
+	for index := source.Len() - 1; index >= count; index-- {
+		d.add(ChangeRemove, d.join(path, fmt.Sprintf("%v", index)), d.valueOf(source.Index(index)), nil)
+	}
+}
+
+func (d *differ) replace(path string, source, target reflect.Value) {
+	d.add(ChangeReplace, path, d.valueOf(source), d.valueOf(target))
+}
+
+// valueOf returns the value of the change. The optionals are reported by their
+// value or nil if it is absent or empty.
+func (d *differ) valueOf(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	if getter, ok := optionalValueOf(value); ok {
+		item, _, valid := getter.optional()
+
+		if !valid {
+			return nil
+		}
+
+		return item.Interface()
+	}
+
+	return value.Interface()
+}
+
+func (d *differ) add(op ChangeOp, path string, from, to interface{}) {
+	d.changes = append(d.changes, Change{
+		Op:   op,
+		Path: path,
+		From: from,
+		To:   to,
+	})
+}
+
+func (d *differ) deref(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+
+		value = value.Elem()
+	}
+
+	return value
+}
+
+func (d *differ) join(path, name string) string {
+	name = strings.ReplaceAll(name, "~", "~0")
+	name = strings.ReplaceAll(name, "/", "~1")
+
+	return path + "/" + name
+}
@@ -0,0 +1,200 @@
+package inflate_test
+
+import (
+	"time"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	type Address struct {
+		City    string `field:"city"`
+		Country string `field:"country"`
+	}
+
+	type Audit struct {
+		Version int `field:"version"`
+	}
+
+	type Account struct {
+		Name    string            `field:"name"`
+		Tags    []string          `field:"tags"`
+		Labels  map[string]string `field:"labels"`
+		Address *Address          `field:"address"`
+		Audit   Audit             `field:"~"`
+	}
+
+	var source, target *Account
+
+	BeforeEach(func() {
+		source = &Account{
+			Name:    "John",
+			Tags:    []string{"admin", "guest", "owner"},
+			Labels:  map[string]string{"env": "prod", "team": "api"},
+			Address: &Address{City: "London", Country: "UK"},
+			Audit:   Audit{Version: 1},
+		}
+
+		target = &Account{
+			Name:    "John",
+			Tags:    []string{"admin", "guest", "owner"},
+			Labels:  map[string]string{"env": "prod", "team": "api"},
+			Address: &Address{City: "London", Country: "UK"},
+			Audit:   Audit{Version: 1},
+		}
+	})
+
+	It("returns the changes successfully", func() {
+		target.Name = "Jack"
+		target.Tags = []string{"admin"}
+		target.Labels = map[string]string{"env": "dev", "zone": "eu"}
+		target.Address.City = "Paris"
+		target.Audit.Version = 2
+
+		Expect(inflate.Diff(source, target)).To(Equal([]inflate.Change{
+			{Op: inflate.ChangeReplace, Path: "/name", From: "John", To: "Jack"},
+			{Op: inflate.ChangeRemove, Path: "/tags/2", From: "owner"},
+			{Op: inflate.ChangeRemove, Path: "/tags/1", From: "guest"},
+			{Op: inflate.ChangeReplace, Path: "/labels/env", From: "prod", To: "dev"},
+			{Op: inflate.ChangeRemove, Path: "/labels/team", From: "api"},
+			{Op: inflate.ChangeAdd, Path: "/labels/zone", To: "eu"},
+			{Op: inflate.ChangeReplace, Path: "/address/city", From: "London", To: "Paris"},
+			{Op: inflate.ChangeReplace, Path: "/version", From: 1, To: 2},
+		}))
+	})
+
+	Context("when the values are equal", func() {
+		It("returns no changes", func() {
+			Expect(inflate.Diff(source, target)).To(BeEmpty())
+		})
+	})
+
+	Context("when the nested struct is removed", func() {
+		It("returns the replaced value", func() {
+			target.Address = nil
+
+			Expect(inflate.Diff(source, target)).To(Equal([]inflate.Change{
+				{Op: inflate.ChangeReplace, Path: "/address", From: Address{City: "London", Country: "UK"}},
+			}))
+		})
+	})
+
+	Context("when the items are added to a slice", func() {
+		It("returns the added values", func() {
+			target.Tags = append(target.Tags, "root")
+
+			Expect(inflate.Diff(source, target)).To(Equal([]inflate.Change{
+				{Op: inflate.ChangeAdd, Path: "/tags/3", To: "root"},
+			}))
+		})
+	})
+
+	Context("when the values are not pointers", func() {
+		type Event struct {
+			Count inflate.Optional[int] `field:"count"`
+			At    time.Time             `field:"at"`
+		}
+
+		It("returns the changes of the optional fields", func() {
+			var from, to Event
+
+			from.Count.Set(1)
+			to.Count.Set(2)
+
+			Expect(inflate.Diff(from, to)).To(ConsistOf(inflate.Change{
+				Op:   inflate.ChangeReplace,
+				Path: "/count",
+				From: 1,
+				To:   2,
+			}))
+		})
+
+		It("returns nil for the empty optional fields", func() {
+			var from, to Event
+
+			from.Count.Set(1)
+			to.Count.Clear()
+
+			changes := inflate.Diff(from, to)
+			Expect(changes).To(ConsistOf(inflate.Change{
+				Op:   inflate.ChangeReplace,
+				Path: "/count",
+				From: 1,
+			}))
+
+			patch, err := inflate.JSONPatch(changes)
+			Expect(err).To(BeNil())
+			Expect(patch).To(MatchJSON(`[{"op": "replace", "path": "/count", "value": null}]`))
+		})
+
+		It("compares the times by their instant", func() {
+			now := time.Now()
+
+			from := Event{At: now}
+			to := Event{At: now.Round(0)}
+
+			Expect(inflate.Diff(from, to)).To(BeEmpty())
+		})
+	})
+
+	Context("when the fields are omitted when empty", func() {
+		type Profile struct {
+			Name     string `json:"name"`
+			Nickname string `json:"nickname,omitempty"`
+			Age      int    `json:"age,omitempty"`
+		}
+
+		It("returns the added and removed values", func() {
+			from := Profile{Name: "John", Age: 30}
+			to := Profile{Name: "John", Nickname: "Johnny"}
+
+			Expect(inflate.Diff(from, to)).To(Equal([]inflate.Change{
+				{Op: inflate.ChangeAdd, Path: "/nickname", To: "Johnny"},
+				{Op: inflate.ChangeRemove, Path: "/age", From: 30},
+			}))
+		})
+	})
+
+	Context("when the map key has to be escaped", func() {
+		It("returns the escaped path", func() {
+			target.Labels["app/~name"] = "web"
+
+			Expect(inflate.Diff(source, target)).To(Equal([]inflate.Change{
+				{Op: inflate.ChangeAdd, Path: "/labels/app~1~0name", To: "web"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("JSONPatch", func() {
+	It("returns the patch successfully", func() {
+		changes := []inflate.Change{
+			{Op: inflate.ChangeReplace, Path: "/name", From: "John", To: "Jack"},
+			{Op: inflate.ChangeRemove, Path: "/tags/1", From: "guest"},
+			{Op: inflate.ChangeAdd, Path: "/labels/zone", To: "eu"},
+		}
+
+		patch, err := inflate.JSONPatch(changes)
+		Expect(err).To(BeNil())
+		Expect(patch).To(MatchJSON(`[
+			{"op": "replace", "path": "/name", "value": "Jack"},
+			{"op": "remove", "path": "/tags/1"},
+			{"op": "add", "path": "/labels/zone", "value": "eu"}
+		]`))
+	})
+
+	Context("when the value cannot be marshaled", func() {
+		It("returns an error", func() {
+			changes := []inflate.Change{
+				{Op: inflate.ChangeAdd, Path: "/fn", To: func() {}},
+			}
+
+			patch, err := inflate.JSONPatch(changes)
+			Expect(err).To(MatchError("json patch: json: unsupported type: func()"))
+			Expect(patch).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package inflate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var enums sync.Map
+
+// RegisterEnum registers an enum that is used by the converters to convert the
+// names to values of the enum's type and vice versa
+func RegisterEnum(enum *Enum) {
+	enums.Store(enum.Type, enum)
+}
+
+// Enum maps the names of a named type's constants to their values
+type Enum struct {
+	Type       reflect.Type
+	Names      []string
+	Values     []interface{}
+	IgnoreCase bool
+}
+
+// EnumOf returns an enum of given names and values
+func EnumOf[T comparable](values map[string]T) *Enum {
+	enum := &Enum{
+		Type: reflect.TypeOf(new(T)).Elem(),
+	}
+
+	for name := range values {
+		enum.Names = append(enum.Names, name)
+	}
+
+	sort.Strings(enum.Names)
+
+	for _, name := range enum.Names {
+		enum.Values = append(enum.Values, values[name])
+	}
+
+	return enum
+}
+
+// EnumOfStringer returns an enum of given values named by their String method
+func EnumOfStringer[T interface {
+	comparable
+	fmt.Stringer
+}](values ...T) *Enum {
+	enum := &Enum{
+		Type: reflect.TypeOf(new(T)).Elem(),
+	}
+
+	for _, value := range values {
+		enum.Names = append(enum.Names, value.String())
+		enum.Values = append(enum.Values, value)
+	}
+
+	return enum
+}
+
+// Value returns the value of given name
+func (e *Enum) Value(name string) (interface{}, bool) {
+	for index, item := range e.Names {
+		if item == name || (e.IgnoreCase && strings.EqualFold(item, name)) {
+			return e.Values[index], true
+		}
+	}
+
+	return nil, false
+}
+
+// Name returns the name of given value
+func (e *Enum) Name(value interface{}) (string, bool) {
+	for index, item := range e.Values {
+		if item == value {
+			return e.Names[index], true
+		}
+	}
+
+	return "", false
+}
+
+// EnumError is returned when a name is not defined by an enum
+type EnumError struct {
+	Type    reflect.Type
+	Name    string
+	Allowed []string
+}
+
+// Error returns the error message
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("enum: name: '%v' not allowed for %v: %v", e.Name, e.Type, e.Allowed)
+}
+
+func enumOf(t reflect.Type) (*Enum, bool) {
+	if enum, ok := enums.Load(t); ok {
+		return enum.(*Enum), true
+	}
+
+	return nil, false
+}
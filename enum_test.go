@@ -0,0 +1,162 @@
+package inflate_test
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusInactive
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusInactive:
+		return "inactive"
+	default:
+		return "unknown"
+	}
+}
+
+type Priority uint8
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+
+func init() {
+	inflate.RegisterEnum(inflate.EnumOfStringer(StatusActive, StatusInactive))
+
+	priority := inflate.EnumOf(map[string]Priority{
+		"low":  PriorityLow,
+		"high": PriorityHigh,
+	})
+	priority.IgnoreCase = true
+
+	inflate.RegisterEnum(priority)
+}
+
+var _ = Describe("Enum", func() {
+	var converter *inflate.Converter
+
+	BeforeEach(func() {
+		converter = &inflate.Converter{
+			TagName: "fake",
+		}
+	})
+
+	It("converts the name to value", func() {
+		var (
+			source = "inactive"
+			target Status
+		)
+
+		Expect(converter.Convert(&source, &target)).To(Succeed())
+		Expect(target).To(Equal(StatusInactive))
+	})
+
+	It("converts the value to name", func() {
+		var (
+			source = PriorityHigh
+			target string
+		)
+
+		Expect(converter.Convert(&source, &target)).To(Succeed())
+		Expect(target).To(Equal("high"))
+	})
+
+	Context("when the enum ignores the case", func() {
+		It("converts the name to value", func() {
+			var (
+				source = "HIGH"
+				target Priority
+			)
+
+			Expect(converter.Convert(&source, &target)).To(Succeed())
+			Expect(target).To(Equal(PriorityHigh))
+		})
+	})
+
+	Context("when the enum does not ignore the case", func() {
+		It("returns an error", func() {
+			var (
+				source = "Active"
+				target Status
+			)
+
+			err := converter.Convert(&source, &target)
+			Expect(err).To(MatchError("cannot convert string 'Active' to int: enum: name: 'Active' not allowed for inflate_test.Status: [active inactive]"))
+
+			enumErr := &inflate.EnumError{}
+			Expect(errors.As(err, &enumErr)).To(BeTrue())
+			Expect(enumErr.Name).To(Equal("Active"))
+			Expect(enumErr.Allowed).To(Equal([]string{"active", "inactive"}))
+		})
+	})
+
+	Context("when the value is not named", func() {
+		It("converts the value to number", func() {
+			var (
+				source = Priority(7)
+				target string
+			)
+
+			Expect(converter.Convert(&source, &target)).To(Succeed())
+			Expect(target).To(Equal("7"))
+		})
+
+		It("converts the number to value", func() {
+			var (
+				source = "1"
+				target Status
+			)
+
+			Expect(converter.Convert(&source, &target)).To(Succeed())
+			Expect(target).To(Equal(Status(1)))
+		})
+	})
+
+	Describe("Decoder", func() {
+		type Filter struct {
+			Status   []Status `query:"status"`
+			Priority Priority `query:"priority"`
+		}
+
+		It("decodes the names successfully", func() {
+			query, err := url.ParseQuery("status=active&status=inactive&priority=low")
+			Expect(err).To(BeNil())
+
+			filter := &Filter{}
+			Expect(inflate.NewQueryDecoder(query).Decode(filter)).To(Succeed())
+			Expect(filter.Status).To(Equal([]Status{StatusActive, StatusInactive}))
+			Expect(filter.Priority).To(Equal(PriorityLow))
+		})
+
+		It("decodes the numbers and the defaults successfully", func() {
+			type Query struct {
+				Status   Status `query:"status"`
+				Fallback Status `query:"fallback" default:"1"`
+			}
+
+			query, err := url.ParseQuery("status=2")
+			Expect(err).To(BeNil())
+
+			value := &Query{}
+			Expect(inflate.NewQueryDecoder(query).Decode(value)).To(Succeed())
+			Expect(inflate.SetDefault(value)).To(Succeed())
+			Expect(value.Status).To(Equal(StatusInactive))
+			Expect(value.Fallback).To(Equal(StatusActive))
+		})
+	})
+})
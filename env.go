@@ -0,0 +1,150 @@
+package inflate
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// NewEnvDecoder creates an environment variable decoder
+func NewEnvDecoder() *Decoder {
+	return &Decoder{
+		TagName: "env",
+		Converter: &Converter{
+			TagName: "env",
+		},
+		Provider: &EnvProvider{},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
+	}
+}
+
+var _ KeyJoiner = &EnvProvider{}
+
+var _ ValueProvider = &EnvProvider{}
+
+// EnvProvider represents a parameter provider that fetches values from
+// the environment variables
+type EnvProvider struct {
+	// Separator joins the names of the prefixed fields (default: "_")
+	Separator string
+}
+
+// JoinKey joins the upper-cased names of a prefixed field (e.g. PAGE_SIZE)
+func (p *EnvProvider) JoinKey(names ...string) string {
+	separator := p.Separator
+
+	if separator == "" {
+		separator = "_"
+	}
+
+	return strings.ToUpper(strings.Join(names, separator))
+}
+
+// Value returns a primitive value
+func (p *EnvProvider) Value(ctx *Context) (interface{}, error) {
+	if ctx.Tag.Name == "" {
+		return nil, nil
+	}
+
+	if convertable(ctx.Type) {
+		return p.valueOf(ctx)
+	}
+
+	switch ctx.Type.Kind() {
+	case reflect.Map, reflect.Struct:
+		return p.mapOf(ctx)
+	case reflect.Array, reflect.Slice:
+		values, err := p.arrayOf(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return convertValue(values), nil
+	default:
+		return p.valueOf(ctx)
+	}
+}
+
+func (p *EnvProvider) valueOf(ctx *Context) (interface{}, error) {
+	value, ok := p.lookup(ctx)
+
+	if !ok {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+func (p *EnvProvider) arrayOf(ctx *Context) ([]interface{}, error) {
+	value, ok := p.lookup(ctx)
+
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		separator = ","
+		parts     = strings.Split(value, separator)
+	)
+
+	return convertArray(parts), nil
+}
+
+func (p *EnvProvider) mapOf(ctx *Context) (m map[string]interface{}, err error) {
+	value, ok := p.lookup(ctx)
+
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		separator = ","
+		parts     = strings.Split(value, separator)
+	)
+
+	if ctx.Tag.HasOption(OptionExplode) {
+		m, err = explodeMap(parts)
+	} else {
+		m, err = convertMap(parts)
+	}
+
+	if err != nil {
+		return nil, p.errorf(err.Error())
+	}
+
+	return m, nil
+}
+
+func (p *EnvProvider) lookup(ctx *Context) (string, bool) {
+	if value, ok := os.LookupEnv(ctx.Tag.Name); ok {
+		return value, true
+	}
+
+	if match := ctx.Match.or(MatchExact); match != MatchExact {
+		var (
+			names  = []string{}
+			values = make(map[string]string)
+		)
+
+		for _, item := range os.Environ() {
+			if parts := strings.SplitN(item, "=", 2); len(parts) == 2 {
+				names = append(names, parts[0])
+				values[parts[0]] = parts[1]
+			}
+		}
+
+		if key, ok := match.find(ctx.Tag.Name, names); ok {
+			return values[key], true
+		}
+	}
+
+	return "", false
+}
+
+func (p *EnvProvider) errorf(msg string, values ...interface{}) error {
+	msg = fmt.Sprintf(msg, values...)
+	return fmt.Errorf("env: %s", msg)
+}
@@ -0,0 +1,150 @@
+package inflate_test
+
+import (
+	"os"
+	"reflect"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Env", func() {
+	var (
+		provider *inflate.EnvProvider
+		ctx      *inflate.Context
+	)
+
+	BeforeEach(func() {
+		ctx = &inflate.Context{
+			Field: "ID",
+			Type:  reflect.TypeOf(""),
+			Tag: &inflate.Tag{
+				Key:  "env",
+				Name: "INFLATE_ID",
+			},
+		}
+
+		provider = &inflate.EnvProvider{}
+
+		Expect(os.Setenv("INFLATE_ID", "5")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "INFLATE_ID")
+	})
+
+	Describe("NewEnvDecoder", func() {
+		It("creates a new env decoder", func() {
+			decoder := inflate.NewEnvDecoder()
+			Expect(decoder).NotTo(BeNil())
+		})
+
+		Context("when the struct field has a prefix", func() {
+			type Pagination struct {
+				Size   int `env:"size"`
+				Number int `env:"number"`
+			}
+
+			type Options struct {
+				Page Pagination `env:"inflate_page,prefix"`
+			}
+
+			BeforeEach(func() {
+				Expect(os.Setenv("INFLATE_PAGE_SIZE", "10")).To(Succeed())
+				DeferCleanup(os.Unsetenv, "INFLATE_PAGE_SIZE")
+			})
+
+			It("decodes the fields from the prefixed variables", func() {
+				options := &Options{}
+				Expect(inflate.NewEnvDecoder().Decode(options)).To(Succeed())
+				Expect(options.Page.Size).To(Equal(10))
+				Expect(options.Page.Number).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the value is primitive type", func() {
+		It("returns the value successfully", func() {
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("5"))
+		})
+
+		Context("when the variable is not found", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "INFLATE_NAME"
+			})
+
+			It("returns a nil value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(BeNil())
+			})
+		})
+	})
+
+	Context("when the value is array type", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("INFLATE_ID", "3,4,5")).To(Succeed())
+			ctx.Type = reflect.TypeOf([]interface{}{})
+		})
+
+		It("returns the value successfully", func() {
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(ConsistOf("3", "4", "5"))
+		})
+	})
+
+	Context("when the value is map type", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv("INFLATE_ID", "role,admin,name,Jack")).To(Succeed())
+			ctx.Type = reflect.TypeOf(map[string]interface{}{})
+		})
+
+		It("returns the value successfully", func() {
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(HaveKeyWithValue("role", "admin"))
+			Expect(value).To(HaveKeyWithValue("name", "Jack"))
+		})
+
+		Context("when the explode option is provided", func() {
+			BeforeEach(func() {
+				Expect(os.Setenv("INFLATE_ID", "role=admin,name=Jack")).To(Succeed())
+				ctx.Tag.Options = []string{"explode"}
+			})
+
+			It("returns the value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(HaveKeyWithValue("role", "admin"))
+				Expect(value).To(HaveKeyWithValue("name", "Jack"))
+			})
+		})
+
+		Context("when the value is invalid", func() {
+			BeforeEach(func() {
+				Expect(os.Setenv("INFLATE_ID", "role")).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(MatchError("env: object value: [role] invalid"))
+				Expect(value).To(BeNil())
+			})
+		})
+	})
+
+	Describe("JoinKey", func() {
+		It("joins the upper-cased names", func() {
+			Expect(provider.JoinKey("page", "size")).To(Equal("PAGE_SIZE"))
+		})
+
+		Context("when the separator is set", func() {
+			It("joins the names with the separator", func() {
+				provider.Separator = "__"
+				Expect(provider.JoinKey("page", "size")).To(Equal("PAGE__SIZE"))
+			})
+		})
+	})
+})
@@ -0,0 +1,262 @@
+package inflate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+)
+
+const (
+	// OptionBase64 is the base64 opt
+	OptionBase64 = "base64"
+)
+
+// NewFileTreeDecoder creates a decoder that reads the values from the files
+// in given directory
+func NewFileTreeDecoder(root string) *Decoder {
+	return &Decoder{
+		TagName: "file",
+		Converter: &Converter{
+			TagName: "file",
+		},
+		Provider: &FileTreeProvider{
+			FileSystem: os.DirFS(root),
+		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
+	}
+}
+
+var _ ContextValueProvider = &FileTreeProvider{}
+
+var _ ValueProvider = &FileTreeProvider{}
+
+var _ KeyJoiner = &FileTreeProvider{}
+
+// FileTreeProvider represents a parameter provider that fetches values from
+// a directory that contains a file per key (e.g. /run/secrets). The struct
+// and map fields are read from the subdirectory with the same name.
+type FileTreeProvider struct {
+	FileSystem fs.FS
+}
+
+// Value returns a primitive value
+func (p *FileTreeProvider) Value(ctx *Context) (interface{}, error) {
+	return p.ValueContext(context.Background(), ctx)
+}
+
+// ValueContext returns a primitive value unless the context is done
+func (p *FileTreeProvider) ValueContext(parent context.Context, ctx *Context) (interface{}, error) {
+	if ctx.Tag.Name == "" {
+		return nil, nil
+	}
+
+	if err := parent.Err(); err != nil {
+		return nil, fmt.Errorf("file: field: '%v' not read: %w", ctx.Tag.Name, err)
+	}
+
+	var (
+		name      = p.lookup(ctx)
+		info, err = fs.Stat(p.FileSystem, name)
+	)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, p.errorf("field: '%v' not read: %v", ctx.Tag.Name, err)
+	}
+
+	if info.IsDir() {
+		switch ctx.Type.Kind() {
+		case reflect.Map, reflect.Struct:
+			return p.tree(name, ctx.Tag.Key, ctx.Type, ctx.Match.or(MatchExact))
+		default:
+			return nil, p.errorf("field: '%v' is a directory", ctx.Tag.Name)
+		}
+	}
+
+	data, err := p.file(name)
+	if err != nil {
+		return nil, p.errorf("field: '%v' not read: %v", ctx.Tag.Name, err)
+	}
+
+	if ctx.Tag.HasOption(OptionBase64) {
+		if data, err = base64.StdEncoding.DecodeString(string(data)); err != nil {
+			return nil, p.errorf("field: '%v' not decoded: %v", ctx.Tag.Name, err)
+		}
+	}
+
+	if convertable(ctx.Type) {
+		return string(data), nil
+	}
+
+	switch ctx.Type.Kind() {
+	case reflect.Map, reflect.Struct:
+		m := make(map[string]interface{})
+
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, p.errorf("field: '%v' not parsed: %v", ctx.Tag.Name, err)
+		}
+
+		return m, nil
+	case reflect.Array, reflect.Slice:
+		if ctx.Type.Elem().Kind() == reflect.Uint8 {
+			return data, nil
+		}
+
+		return convertValue(convertArray(strings.Split(string(data), "\n"))), nil
+	default:
+		return string(data), nil
+	}
+}
+
+// JoinKey joins the names of a prefixed field as a path (e.g. db/password)
+func (p *FileTreeProvider) JoinKey(names ...string) string {
+	return path.Join(names...)
+}
+
+// tree reads the directory as a map. The options of the struct fields read
+// from the directory (e.g. base64) are applied to their files.
+func (p *FileTreeProvider) tree(dir, tagName string, t reflect.Type, match NameMatch) (map[string]interface{}, error) {
+	entries, err := fs.ReadDir(p.FileSystem, dir)
+	if err != nil {
+		return nil, p.errorf("directory: '%v' not read: %v", dir, err)
+	}
+
+	var (
+		result = make(map[string]interface{})
+		fields = fieldsOfType(tagName, t)
+		keys   = []string{}
+	)
+
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	for _, entry := range entries {
+		// the kubernetes volumes keep the files in the ..data directory and
+		// link them to the top level
+		if strings.HasPrefix(entry.Name(), "..") {
+			continue
+		}
+
+		name := path.Join(dir, entry.Name())
+
+		// the symbolic links are resolved by stat
+		info, err := fs.Stat(p.FileSystem, name)
+		if err != nil {
+			return nil, p.errorf("field: '%v' not read: %v", name, err)
+		}
+
+		field, ok := fields[entry.Name()]
+
+		if !ok {
+			if key, found := match.find(entry.Name(), keys); found {
+				field = fields[key]
+			}
+		}
+
+		if info.IsDir() {
+			var next reflect.Type
+
+			if field != nil {
+				next = field.Value.Type()
+			}
+
+			if result[entry.Name()], err = p.tree(name, tagName, next, match); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		data, err := p.file(name)
+		if err != nil {
+			return nil, p.errorf("field: '%v' not read: %v", name, err)
+		}
+
+		if field != nil && field.Tag.HasOption(OptionBase64) {
+			if data, err = base64.StdEncoding.DecodeString(string(data)); err != nil {
+				return nil, p.errorf("field: '%v' not decoded: %v", name, err)
+			}
+
+			// the decoded bytes are kept for the []byte fields only
+			if item := field.Value.Type(); item.Kind() == reflect.Slice && item.Elem().Kind() == reflect.Uint8 {
+				result[entry.Name()] = data
+				continue
+			}
+		}
+
+		result[entry.Name()] = string(data)
+	}
+
+	return result, nil
+}
+
+// lookup returns the path of the file that matches the tag name. Each
+// segment of the path is matched with the strategy of the context.
+func (p *FileTreeProvider) lookup(ctx *Context) string {
+	match := ctx.Match.or(MatchExact)
+
+	if match == MatchExact {
+		return ctx.Tag.Name
+	}
+
+	name := "."
+
+	for _, part := range strings.Split(ctx.Tag.Name, "/") {
+		name = path.Join(name, p.find(name, part, match))
+	}
+
+	return name
+}
+
+// find returns the entry of the directory that matches the name
+func (p *FileTreeProvider) find(dir, name string, match NameMatch) string {
+	entries, err := fs.ReadDir(p.FileSystem, dir)
+	if err != nil {
+		return name
+	}
+
+	names := []string{}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "..") {
+			names = append(names, entry.Name())
+		}
+	}
+
+	if key, ok := match.find(name, names); ok {
+		return key
+	}
+
+	return name
+}
+
+func (p *FileTreeProvider) file(name string) ([]byte, error) {
+	data, err := fs.ReadFile(p.FileSystem, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the editors and the secret tools append a new line
+	for len(data) > 0 && (data[len(data)-1] == '\n' || data[len(data)-1] == '\r') {
+		data = data[:len(data)-1]
+	}
+
+	return data, nil
+}
+
+func (p *FileTreeProvider) errorf(msg string, values ...interface{}) error {
+	msg = fmt.Sprintf(msg, values...)
+	return fmt.Errorf("file: %s", msg)
+}
@@ -0,0 +1,222 @@
+package inflate_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing/fstest"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileTree", func() {
+	Describe("NewFileTreeDecoder", func() {
+		type Database struct {
+			Username string `file:"username"`
+			Password string `file:"password"`
+			Cert     []byte `file:"cert,base64"`
+			Secret   string `file:"secret,base64"`
+		}
+
+		type Secrets struct {
+			Token    string   `file:"token"`
+			Key      []byte   `file:"key,base64"`
+			Database Database `file:"database"`
+			Missing  string   `file:"missing"`
+		}
+
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+
+			Expect(os.WriteFile(filepath.Join(dir, "token"), []byte("secret\n"), 0o600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "key"), []byte("aW5mbGF0ZQ==\n"), 0o600)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(dir, "database"), 0o700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "database", "username"), []byte("root\r\n"), 0o600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "database", "password"), []byte("swordfish"), 0o600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "database", "cert"), []byte("Y2VydA=="), 0o600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "database", "secret"), []byte("c3dvcmRmaXNo"), 0o600)).To(Succeed())
+		})
+
+		It("decodes the directory successfully", func() {
+			secrets := &Secrets{}
+
+			Expect(inflate.NewFileTreeDecoder(dir).Decode(secrets)).To(Succeed())
+			Expect(secrets.Token).To(Equal("secret"))
+			Expect(secrets.Key).To(Equal([]byte("inflate")))
+			Expect(secrets.Database.Username).To(Equal("root"))
+			Expect(secrets.Database.Password).To(Equal("swordfish"))
+			Expect(secrets.Database.Cert).To(Equal([]byte("cert")))
+			Expect(secrets.Database.Secret).To(Equal("swordfish"))
+			Expect(secrets.Missing).To(BeEmpty())
+		})
+
+		Context("when the struct field has a prefix", func() {
+			It("reads the files from the subdirectory", func() {
+				type Settings struct {
+					Database Database `file:"database,prefix"`
+				}
+
+				settings := &Settings{}
+
+				Expect(inflate.NewFileTreeDecoder(dir).Decode(settings)).To(Succeed())
+				Expect(settings.Database.Username).To(Equal("root"))
+				Expect(settings.Database.Password).To(Equal("swordfish"))
+				Expect(settings.Database.Cert).To(Equal([]byte("cert")))
+			})
+		})
+
+		Context("when the directory is a kubernetes volume", func() {
+			BeforeEach(func() {
+				var (
+					volume = filepath.Join(dir, "db")
+					data   = filepath.Join(volume, "..2026_10_18_00_00_00.000000000")
+				)
+
+				Expect(os.MkdirAll(data, 0o700)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(data, "username"), []byte("admin"), 0o600)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(data, "cert"), []byte("Y2VydA=="), 0o600)).To(Succeed())
+				Expect(os.Symlink(filepath.Base(data), filepath.Join(volume, "..data"))).To(Succeed())
+				Expect(os.Symlink(filepath.Join("..data", "username"), filepath.Join(volume, "username"))).To(Succeed())
+				Expect(os.Symlink(filepath.Join("..data", "cert"), filepath.Join(volume, "cert"))).To(Succeed())
+			})
+
+			It("reads the linked files", func() {
+				type Volume struct {
+					Database Database `file:"db"`
+				}
+
+				volume := &Volume{}
+
+				Expect(inflate.NewFileTreeDecoder(dir).Decode(volume)).To(Succeed())
+				Expect(volume.Database.Username).To(Equal("admin"))
+				Expect(volume.Database.Cert).To(Equal([]byte("cert")))
+			})
+		})
+	})
+
+	Describe("FileTreeProvider", func() {
+		var (
+			provider *inflate.FileTreeProvider
+			ctx      *inflate.Context
+		)
+
+		BeforeEach(func() {
+			ctx = &inflate.Context{
+				Field: "Token",
+				Type:  reflect.TypeOf(""),
+				Tag: &inflate.Tag{
+					Key:  "file",
+					Name: "token",
+				},
+			}
+
+			provider = &inflate.FileTreeProvider{
+				FileSystem: fstest.MapFS{
+					"token":       {Data: []byte("secret\n")},
+					"hosts":       {Data: []byte("one\ntwo\n")},
+					"labels":      {Data: []byte(`{"env": "prod"}`)},
+					"config/port": {Data: []byte("5432")},
+				},
+			}
+		})
+
+		It("returns the value successfully", func() {
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("secret"))
+		})
+
+		Context("when the context is canceled", func() {
+			It("returns an error", func() {
+				parent, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				value, err := provider.ValueContext(parent, ctx)
+				Expect(err).To(MatchError("file: field: 'token' not read: context canceled"))
+				Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+				Expect(value).To(BeNil())
+			})
+		})
+
+		Context("when the file is not found", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "password"
+			})
+
+			It("returns a nil value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(BeNil())
+			})
+		})
+
+		Context("when the base64 option is provided", func() {
+			BeforeEach(func() {
+				ctx.Tag.Options = []string{"base64"}
+			})
+
+			It("returns an error", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(MatchError("file: field: 'token' not decoded: illegal base64 data at input byte 4"))
+				Expect(value).To(BeNil())
+			})
+		})
+
+		Context("when the value is array type", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "hosts"
+				ctx.Type = reflect.TypeOf([]string{})
+			})
+
+			It("returns the lines successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(Equal([]interface{}{"one", "two"}))
+			})
+		})
+
+		Context("when the value is map type", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "labels"
+				ctx.Type = reflect.TypeOf(map[string]string{})
+			})
+
+			It("returns the value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(HaveKeyWithValue("env", "prod"))
+			})
+
+			Context("when the file is directory", func() {
+				BeforeEach(func() {
+					ctx.Tag.Name = "config"
+				})
+
+				It("returns the value successfully", func() {
+					value, err := provider.Value(ctx)
+					Expect(err).To(BeNil())
+					Expect(value).To(HaveKeyWithValue("port", "5432"))
+				})
+			})
+		})
+
+		Context("when the file is directory", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "config"
+			})
+
+			It("returns an error", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(MatchError("file: field: 'config' is a directory"))
+				Expect(value).To(BeNil())
+			})
+		})
+	})
+})
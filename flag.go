@@ -0,0 +1,255 @@
+package inflate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// NewFlagDecoder creates a flag decoder
+func NewFlagDecoder(flags *flag.FlagSet, args []string) *FlagDecoder {
+	return &FlagDecoder{
+		FlagSet: flags,
+		Args:    args,
+	}
+}
+
+// ParseFlags parses the command-line arguments into the target
+func ParseFlags(target interface{}) error {
+	return NewFlagDecoder(flag.CommandLine, os.Args[1:]).Decode(target)
+}
+
+// FlagDecoder registers the fields of a struct as flags, parses the arguments
+// and decodes the flag values into the struct
+type FlagDecoder struct {
+	FlagSet *flag.FlagSet
+	Args    []string
+}
+
+// Decode decodes the values to given target
+func (d *FlagDecoder) Decode(value interface{}) error {
+	target, err := check("target", value)
+	if err != nil {
+		return err
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsZero() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+
+		target = target.Elem()
+	}
+
+	if err := d.register(StructOf("flag", target)); err != nil {
+		return err
+	}
+
+	if err := d.FlagSet.Parse(d.Args); err != nil {
+		return err
+	}
+
+	// the defaults are set first so the flags that are set explicitly
+	// override them even with their zero values
+	if err := SetDefault(target); err != nil {
+		return err
+	}
+
+	provider := &FlagProvider{
+		FlagSet: d.FlagSet,
+	}
+
+	// the flag values replace the default slices and maps
+	d.reset(StructOf("flag", target), provider)
+
+	decoder := &Decoder{
+		TagName: "flag",
+		Converter: &Converter{
+			TagName: "flag",
+		},
+		Provider: provider,
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
+	}
+
+	return decoder.Decode(target)
+}
+
+func (d *FlagDecoder) register(ch *Struct) error {
+	provider := &DefaultProvider{}
+
+	for _, field := range ch.Fields() {
+		target := refer(field.Value)
+
+		if field.Tag.Name == "~" {
+			if kind(target) == reflect.Struct {
+				if err := d.register(field.inline(ch.TagName, target)); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if d.FlagSet.Lookup(field.Tag.Name) != nil {
+			return fmt.Errorf("flag: field: '%v' already defined", field.Tag.Name)
+		}
+
+		definition := field.StructField(ch.Value.Type())
+
+		value, err := provider.Value(&Context{
+			Field:  field.Name,
+			Type:   target.Type(),
+			IsZero: true,
+			Tag:    ParseTag("default", definition.Tag.Get("default")),
+		})
+		if err != nil {
+			return err
+		}
+
+		var (
+			usage, _ = field.Tag.Lookup("usage")
+			param    = &flagValue{
+				boolean: target.Kind() == reflect.Bool,
+			}
+		)
+
+		switch text := value.(type) {
+		case string:
+			param.text = text
+		case json.RawMessage:
+			param.text = string(text)
+		}
+
+		d.FlagSet.Var(param, field.Tag.Name, usage)
+	}
+
+	return nil
+}
+
+// reset clears the slices and maps of the flags that are set since the
+// converter appends to them
+func (d *FlagDecoder) reset(ch *Struct, provider *FlagProvider) {
+	for _, field := range ch.Fields() {
+		if field.Tag.Name == "~" {
+			if target := elem(field.Value); kind(target) == reflect.Struct {
+				d.reset(field.inline(ch.TagName, target), provider)
+			}
+
+			continue
+		}
+
+		switch kind(elem(field.Value)) {
+		case reflect.Slice, reflect.Map:
+			if len(provider.flagArray(field.Tag.Name, MatchExact)) > 0 {
+				field.Value.Set(reflect.Zero(field.Value.Type()))
+			}
+		}
+	}
+}
+
+var _ ValueProvider = &FlagProvider{}
+
+// FlagProvider represents a parameter provider that fetches values from
+// the command-line flags
+type FlagProvider struct {
+	FlagSet *flag.FlagSet
+}
+
+// Value returns a primitive value
+func (p *FlagProvider) Value(ctx *Context) (interface{}, error) {
+	if ctx.Tag.Name == "" {
+		return nil, nil
+	}
+
+	values := p.flagArray(ctx.Tag.Name, ctx.Match)
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	if convertable(ctx.Type) {
+		return values[len(values)-1], nil
+	}
+
+	switch ctx.Type.Kind() {
+	case reflect.Map:
+		m, err := explodeMap(values)
+		if err != nil {
+			return nil, p.errorf(err.Error())
+		}
+
+		return m, nil
+	case reflect.Struct:
+		return json.RawMessage(values[len(values)-1]), nil
+	case reflect.Array, reflect.Slice:
+		return convertValue(convertArray(values)), nil
+	default:
+		return values[len(values)-1], nil
+	}
+}
+
+func (p *FlagProvider) flagArray(name string, match NameMatch) []string {
+	var (
+		names  = []string{}
+		values = make(map[string][]string)
+	)
+
+	// only the flags that have been set are visited
+	p.FlagSet.Visit(func(item *flag.Flag) {
+		names = append(names, item.Name)
+
+		switch param := item.Value.(type) {
+		case *flagValue:
+			values[item.Name] = param.values
+		case flag.Getter:
+			values[item.Name] = []string{fmt.Sprintf("%v", param.Get())}
+		default:
+			values[item.Name] = []string{param.String()}
+		}
+	})
+
+	if key, ok := match.or(MatchExact).find(name, names); ok {
+		return values[key]
+	}
+
+	return nil
+}
+
+func (p *FlagProvider) errorf(msg string, values ...interface{}) error {
+	msg = fmt.Sprintf(msg, values...)
+	return fmt.Errorf("flag: %s", msg)
+}
+
+var _ flag.Value = &flagValue{}
+
+type flagValue struct {
+	text    string
+	values  []string
+	boolean bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	if len(v.values) == 0 {
+		return v.text
+	}
+
+	return strings.Join(v.values, ",")
+}
+
+func (v *flagValue) Set(value string) error {
+	v.values = append(v.values, value)
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.boolean
+}
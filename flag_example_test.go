@@ -0,0 +1,29 @@
+package inflate_test
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/phogolabs/inflate"
+)
+
+func ExampleFlagDecoder() {
+	type Options struct {
+		Addr    string `flag:"addr,usage=the listen address" default:":8080"`
+		Verbose bool   `flag:"verbose,usage=enables the verbose output"`
+	}
+
+	var (
+		flags   = flag.NewFlagSet("example", flag.ContinueOnError)
+		options = &Options{}
+	)
+
+	if err := inflate.NewFlagDecoder(flags, []string{"-verbose"}).Decode(options); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%+v", options)
+
+	// Output:
+	// &{Addr::8080 Verbose:true}
+}
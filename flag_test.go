@@ -0,0 +1,193 @@
+package inflate_test
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Command struct {
+	Tags   []string          `flag:"tag"`
+	Labels map[string]string `flag:"label"`
+}
+
+func (c *Command) SetDefaults() {
+	c.Tags = []string{"default"}
+	c.Labels = map[string]string{"env": "dev", "team": "core"}
+}
+
+var _ = Describe("Flag", func() {
+	var flags *flag.FlagSet
+
+	BeforeEach(func() {
+		flags = flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+	})
+
+	Describe("FlagDecoder", func() {
+		type Database struct {
+			URL string `flag:"database-url,usage=the database url" default:"postgres://localhost"`
+		}
+
+		type Options struct {
+			Addr     string            `flag:"addr,usage=the listen address" default:":8080"`
+			Verbose  bool              `flag:"verbose"`
+			Timeout  time.Duration     `flag:"timeout"`
+			Tags     []string          `flag:"tag"`
+			Labels   map[string]string `flag:"label"`
+			Database *Database         `flag:"~" default:"~"`
+		}
+
+		It("decodes the flags successfully", func() {
+			args := []string{
+				"-addr", ":9090",
+				"-verbose",
+				"-timeout", "30s",
+				"-tag", "a",
+				"-tag", "b",
+				"-label", "env=prod",
+				"-database-url", "postgres://remote",
+			}
+
+			options := &Options{}
+			Expect(inflate.NewFlagDecoder(flags, args).Decode(options)).To(Succeed())
+			Expect(options.Addr).To(Equal(":9090"))
+			Expect(options.Verbose).To(BeTrue())
+			Expect(options.Timeout).To(Equal(30 * time.Second))
+			Expect(options.Tags).To(ConsistOf("a", "b"))
+			Expect(options.Labels).To(HaveKeyWithValue("env", "prod"))
+			Expect(options.Database).NotTo(BeNil())
+			Expect(options.Database.URL).To(Equal("postgres://remote"))
+		})
+
+		It("registers the flags with their usage and defaults", func() {
+			options := &Options{}
+			Expect(inflate.NewFlagDecoder(flags, []string{}).Decode(options)).To(Succeed())
+
+			item := flags.Lookup("addr")
+			Expect(item).NotTo(BeNil())
+			Expect(item.Usage).To(Equal("the listen address"))
+			Expect(item.DefValue).To(Equal(":8080"))
+		})
+
+		Context("when the flags are not provided", func() {
+			It("sets the default values", func() {
+				options := &Options{}
+				Expect(inflate.NewFlagDecoder(flags, []string{}).Decode(options)).To(Succeed())
+				Expect(options.Addr).To(Equal(":8080"))
+				Expect(options.Verbose).To(BeFalse())
+				Expect(options.Database.URL).To(Equal("postgres://localhost"))
+			})
+		})
+
+		Context("when the flags are set to their zero values", func() {
+			type Settings struct {
+				Verbose bool `flag:"verbose" default:"true"`
+				Port    int  `flag:"port" default:"8080"`
+			}
+
+			It("keeps the explicit values", func() {
+				settings := &Settings{}
+				Expect(inflate.NewFlagDecoder(flags, []string{"-verbose=false", "-port=0"}).Decode(settings)).To(Succeed())
+				Expect(settings.Verbose).To(BeFalse())
+				Expect(settings.Port).To(BeZero())
+			})
+		})
+
+		Context("when the slices and maps have defaults", func() {
+			It("replaces them with the flag values", func() {
+				command := &Command{}
+				Expect(inflate.NewFlagDecoder(flags, []string{"-tag", "z", "-label", "env=prod"}).Decode(command)).To(Succeed())
+				Expect(command.Tags).To(Equal([]string{"z"}))
+				Expect(command.Labels).To(Equal(map[string]string{"env": "prod"}))
+			})
+
+			Context("when the flags are not provided", func() {
+				It("keeps the defaults", func() {
+					command := &Command{}
+					Expect(inflate.NewFlagDecoder(flags, []string{}).Decode(command)).To(Succeed())
+					Expect(command.Tags).To(Equal([]string{"default"}))
+					Expect(command.Labels).To(Equal(map[string]string{"env": "dev", "team": "core"}))
+				})
+			})
+		})
+
+		Context("when the flag is unknown", func() {
+			It("returns an error", func() {
+				options := &Options{}
+				Expect(inflate.NewFlagDecoder(flags, []string{"-unknown"}).Decode(options)).To(MatchError("flag provided but not defined: -unknown"))
+			})
+		})
+
+		Context("when the flag is already defined", func() {
+			BeforeEach(func() {
+				flags.String("addr", "", "")
+			})
+
+			It("returns an error", func() {
+				options := &Options{}
+				Expect(inflate.NewFlagDecoder(flags, []string{}).Decode(options)).To(MatchError("flag: field: 'addr' already defined"))
+			})
+		})
+	})
+
+	Describe("FlagProvider", func() {
+		var (
+			provider *inflate.FlagProvider
+			ctx      *inflate.Context
+		)
+
+		BeforeEach(func() {
+			flags.String("id", "", "")
+
+			ctx = &inflate.Context{
+				Field: "ID",
+				Type:  reflect.TypeOf(""),
+				Tag: &inflate.Tag{
+					Key:  "flag",
+					Name: "id",
+				},
+			}
+
+			provider = &inflate.FlagProvider{
+				FlagSet: flags,
+			}
+		})
+
+		It("returns the value successfully", func() {
+			Expect(flags.Parse([]string{"-id", "5"})).To(Succeed())
+
+			value, err := provider.Value(ctx)
+			Expect(err).To(BeNil())
+			Expect(value).To(Equal("5"))
+		})
+
+		Context("when the flag is not set", func() {
+			It("returns a nil value successfully", func() {
+				Expect(flags.Parse([]string{})).To(Succeed())
+
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(BeNil())
+			})
+		})
+
+		Context("when the flag is not defined", func() {
+			BeforeEach(func() {
+				ctx.Tag.Name = "name"
+			})
+
+			It("returns a nil value successfully", func() {
+				value, err := provider.Value(ctx)
+				Expect(err).To(BeNil())
+				Expect(value).To(BeNil())
+			})
+		})
+	})
+})
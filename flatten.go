@@ -0,0 +1,195 @@
+package inflate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Flatten returns the struct as single level map. The keys of the nested
+// structs, maps and slices are joined with the separator (e.g. address.city
+// or items.0.id).
+func (s *Struct) Flatten(separator string) map[string]interface{} {
+	items := make(map[string]interface{})
+	s.flatten(s, "", separator, items)
+	return items
+}
+
+func (s *Struct) flatten(ch *Struct, prefix, separator string, kv map[string]interface{}) {
+	for _, field := range ch.Fields() {
+		if field.Tag.Name == "~" {
+			value := elem(field.Value)
+
+			if kind(value) == reflect.Struct {
+				s.flatten(StructOf(s.TagName, value), prefix, separator, kv)
+			}
+
+			continue
+		}
+
+		if field.Tag.HasOption("omitempty") {
+			if field.IsZero() {
+				continue
+			}
+		}
+
+		s.flattenValue(prefix+field.Tag.Name, separator, field.Value, kv)
+	}
+}
+
+func (s *Struct) flattenValue(key, separator string, value reflect.Value, kv map[string]interface{}) {
+	item := value
+
+	for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			break
+		}
+
+		item = item.Elem()
+	}
+
+	switch item.Kind() {
+	case reflect.Struct:
+		if isOptional(item.Type()) || convertable(item.Type()) {
+			break
+		}
+
+		s.flatten(StructOf(s.TagName, item), key+separator, separator, kv)
+		return
+	case reflect.Map:
+		if item.Len() == 0 {
+			break
+		}
+
+		iter := item.MapRange()
+
+		for iter.Next() {
+			name := fmt.Sprintf("%v", iter.Key().Interface())
+			s.flattenValue(key+separator+name, separator, iter.Value(), kv)
+		}
+
+		return
+	case reflect.Array, reflect.Slice:
+		if item.Len() == 0 || item.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+
+		for index := 0; index < item.Len(); index++ {
+			name := strconv.Itoa(index)
+			s.flattenValue(key+separator+name, separator, item.Index(index), kv)
+		}
+
+		return
+	}
+
+	kv[key] = value.Interface()
+}
+
+// Unflatten sets the values of a map produced by Struct.Flatten to the target.
+// The fields are matched by their field tag or their json tag if it is absent.
+func Unflatten(target interface{}, source map[string]interface{}, separator string) error {
+	to, err := check("target", target)
+	if err != nil {
+		return err
+	}
+
+	return unflattenTo(to, "field,json", source, separator)
+}
+
+// Unflatten sets the values of a map produced by Flatten to the struct
+func (s *Struct) Unflatten(source map[string]interface{}, separator string) error {
+	if !s.Value.CanSet() {
+		return fmt.Errorf("the target must be addressable (a pointer)")
+	}
+
+	return unflattenTo(s.Value, s.TagName, source, separator)
+}
+
+func unflattenTo(to reflect.Value, tagName string, source map[string]interface{}, separator string) error {
+	tree := make(map[string]interface{})
+
+	for key, value := range source {
+		var (
+			parts = strings.Split(key, separator)
+			node  = tree
+		)
+
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := node[part].(map[string]interface{})
+
+			if !ok {
+				next = make(map[string]interface{})
+				node[part] = next
+			}
+
+			node = next
+		}
+
+		node[parts[len(parts)-1]] = value
+	}
+
+	converter := &Converter{
+		TagName: tagName,
+	}
+
+	return converter.convert(reflect.ValueOf(unflatten(tree, converter.TagName, to.Type())), to)
+}
+
+// unflatten turns the maps into slices where the target type is an array or
+// a slice. The maps of the interfaces are slices if they are keyed by the
+// indexes from 0 to n-1.
+func unflatten(value interface{}, tagName string, t reflect.Type) interface{} {
+	node, ok := value.(map[string]interface{})
+
+	if !ok {
+		return value
+	}
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var fields map[string]*Field
+
+	if t != nil && t.Kind() == reflect.Struct {
+		fields = fieldsOfType(tagName, t)
+	}
+
+	for key, item := range node {
+		var next reflect.Type
+
+		switch {
+		case fields != nil:
+			if field, ok := fields[key]; ok {
+				next = field.Value.Type()
+			}
+		case t != nil && (t.Kind() == reflect.Map || t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+			next = t.Elem()
+		}
+
+		node[key] = unflatten(item, tagName, next)
+	}
+
+	if t != nil && t.Kind() != reflect.Interface && t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return node
+	}
+
+	items := make([]interface{}, len(node))
+
+	for key, item := range node {
+		index, err := strconv.Atoi(key)
+
+		if err != nil || index < 0 || index >= len(items) {
+			return node
+		}
+
+		items[index] = item
+	}
+
+	if len(items) == 0 {
+		return node
+	}
+
+	return items
+}
@@ -0,0 +1,162 @@
+package inflate_test
+
+import (
+	"reflect"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flatten", func() {
+	type Address struct {
+		City    string `field:"city"`
+		Country string `field:"country,omitempty"`
+	}
+
+	type Item struct {
+		ID       string `field:"id"`
+		Quantity int    `field:"quantity"`
+	}
+
+	type Audit struct {
+		Version int `field:"version"`
+	}
+
+	type Order struct {
+		Name    string            `field:"name"`
+		Address *Address          `field:"address"`
+		Items   []Item            `field:"items"`
+		Labels  map[string]string `field:"labels"`
+		Note    string            `field:"note,omitempty"`
+		Audit   Audit             `field:"~"`
+	}
+
+	var order *Order
+
+	BeforeEach(func() {
+		order = &Order{
+			Name:    "book",
+			Address: &Address{City: "London"},
+			Items: []Item{
+				{ID: "a", Quantity: 1},
+				{ID: "b", Quantity: 2},
+			},
+			Labels: map[string]string{"env": "prod"},
+			Audit:  Audit{Version: 3},
+		}
+	})
+
+	It("flattens the struct successfully", func() {
+		values := inflate.StructOf("field", reflect.ValueOf(order).Elem()).Flatten(".")
+
+		Expect(values).To(Equal(map[string]interface{}{
+			"name":             "book",
+			"address.city":     "London",
+			"items.0.id":       "a",
+			"items.0.quantity": 1,
+			"items.1.id":       "b",
+			"items.1.quantity": 2,
+			"labels.env":       "prod",
+			"version":          3,
+		}))
+	})
+
+	Context("when the nested values are empty", func() {
+		It("keeps the empty values", func() {
+			order.Address = nil
+			order.Items = nil
+
+			values := inflate.StructOf("field", reflect.ValueOf(order).Elem()).Flatten("_")
+			Expect(values).To(HaveKeyWithValue("address", BeNil()))
+			Expect(values).To(HaveKeyWithValue("items", BeNil()))
+			Expect(values).To(HaveKeyWithValue("labels_env", "prod"))
+		})
+	})
+
+	Context("when the struct is not addressable", func() {
+		type Event struct {
+			Count inflate.Optional[int] `field:"count"`
+		}
+
+		It("keeps the optional fields", func() {
+			event := Event{}
+			event.Count.Set(5)
+
+			values := inflate.StructOf("field", reflect.ValueOf(event)).Flatten(".")
+			Expect(values).To(HaveKeyWithValue("count", event.Count))
+		})
+	})
+
+	Describe("Unflatten", func() {
+		It("unflattens the values successfully", func() {
+			values := inflate.StructOf("field", reflect.ValueOf(order).Elem()).Flatten(".")
+
+			target := &Order{}
+			Expect(inflate.Unflatten(target, values, ".")).To(Succeed())
+			Expect(target).To(Equal(order))
+		})
+
+		Context("when the map is keyed by indexes", func() {
+			type Scores struct {
+				Values map[string]int `field:"values"`
+			}
+
+			It("unflattens the map", func() {
+				scores := &Scores{Values: map[string]int{"0": 5, "1": 6}}
+				values := inflate.StructOf("field", reflect.ValueOf(scores).Elem()).Flatten(".")
+
+				target := &Scores{}
+				Expect(inflate.Unflatten(target, values, ".")).To(Succeed())
+				Expect(target).To(Equal(scores))
+			})
+		})
+
+		Context("when the struct has another tag", func() {
+			type Server struct {
+				Host string `cfg:"host"`
+				Port int    `cfg:"port"`
+			}
+
+			type Config struct {
+				Name   string   `cfg:"name"`
+				Server Server   `cfg:"server"`
+				Hosts  []string `cfg:"hosts"`
+			}
+
+			It("unflattens the values with the struct's tag", func() {
+				config := &Config{
+					Name:   "api",
+					Server: Server{Host: "localhost", Port: 8080},
+					Hosts:  []string{"a", "b"},
+				}
+
+				values := inflate.StructOf("cfg", reflect.ValueOf(config).Elem()).Flatten(".")
+				Expect(values).To(HaveKeyWithValue("server.port", 8080))
+
+				target := &Config{}
+				Expect(inflate.StructOf("cfg", reflect.ValueOf(target).Elem()).Unflatten(values, ".")).To(Succeed())
+				Expect(target).To(Equal(config))
+			})
+
+			Context("when the struct is not addressable", func() {
+				It("returns an error", func() {
+					err := inflate.StructOf("cfg", reflect.ValueOf(Config{})).Unflatten(map[string]interface{}{}, ".")
+					Expect(err).To(MatchError("the target must be addressable (a pointer)"))
+				})
+			})
+		})
+
+		Context("when the value cannot be converted", func() {
+			It("returns an error", func() {
+				values := map[string]interface{}{
+					"items.0.quantity": "many",
+				}
+
+				target := &Order{}
+				Expect(inflate.Unflatten(target, values, ".")).To(MatchError(`cannot convert string 'many' to int: strconv.ParseInt: parsing "many": invalid syntax`))
+			})
+		})
+	})
+})
@@ -3,10 +3,13 @@ module github.com/phogolabs/inflate
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/go-chi/chi/v5 v5.0.8
+	github.com/jinzhu/copier v0.3.5
 	github.com/onsi/ginkgo/v2 v2.6.1
 	github.com/onsi/gomega v1.24.2
 	github.com/phogolabs/schema v0.0.0-20221219132603-9818dada473d
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -16,7 +19,6 @@ require (
 	github.com/go-logr/logr v1.2.3 // indirect
 	github.com/gofrs/uuid v4.3.1+incompatible // indirect
 	github.com/google/go-cmp v0.5.9 // indirect
-	github.com/jinzhu/copier v0.3.5 // indirect
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/leodido/go-urn v1.2.1 // indirect
 	github.com/rs/xid v1.4.0 // indirect
@@ -28,5 +30,4 @@ require (
 	golang.org/x/text v0.5.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -18,9 +18,14 @@ func NewHeaderDecoder(header http.Header) *Decoder {
 		Provider: &HeaderProvider{
 			Header: header,
 		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
 	}
 }
 
+var _ ValueChecker = &HeaderProvider{}
+
 var _ ValueProvider = &HeaderProvider{}
 
 // HeaderProvider represents a parameter provider that fetches values from
@@ -58,8 +63,25 @@ func (p *HeaderProvider) Value(ctx *Context) (interface{}, error) {
 	}
 }
 
+// Check checks whether the options are supported for the field's type
+func (p *HeaderProvider) Check(ctx *Context) error {
+	if ctx.Tag.Name == "" {
+		return nil
+	}
+
+	if len(ctx.Tag.Options) == 0 {
+		ctx.Tag.AddOption(OptionSimple)
+	}
+
+	if !ctx.Tag.HasOption(OptionSimple) {
+		return p.notProvided(ctx, OptionSimple)
+	}
+
+	return nil
+}
+
 func (p *HeaderProvider) valueOf(ctx *Context) (interface{}, error) {
-	header := p.header(ctx.Tag.Name)
+	header := p.header(ctx)
 
 	if header == nil {
 		return nil, nil
@@ -73,7 +95,7 @@ func (p *HeaderProvider) valueOf(ctx *Context) (interface{}, error) {
 }
 
 func (p *HeaderProvider) arrayOf(ctx *Context) ([]interface{}, error) {
-	header := p.header(ctx.Tag.Name)
+	header := p.header(ctx)
 
 	if header == nil {
 		return nil, nil
@@ -97,7 +119,7 @@ func (p *HeaderProvider) arrayOf(ctx *Context) ([]interface{}, error) {
 }
 
 func (p *HeaderProvider) mapOf(ctx *Context) (m map[string]interface{}, err error) {
-	header := p.header(ctx.Tag.Name)
+	header := p.header(ctx)
 
 	if header == nil {
 		return nil, nil
@@ -125,11 +147,32 @@ func (p *HeaderProvider) mapOf(ctx *Context) (m map[string]interface{}, err erro
 	return m, err
 }
 
-func (p *HeaderProvider) header(name string) *string {
-	key := textproto.CanonicalMIMEHeaderKey(name)
+func (p *HeaderProvider) header(ctx *Context) *string {
+	// the header keys are canonical by default
+	if ctx.Match == MatchDefault {
+		key := textproto.CanonicalMIMEHeaderKey(ctx.Tag.Name)
+
+		if _, ok := p.Header[key]; ok {
+			value := p.Header.Get(key)
+			return &value
+		}
+
+		return nil
+	}
+
+	names := make([]string, 0, len(p.Header))
+
+	for name := range p.Header {
+		names = append(names, name)
+	}
+
+	if key, ok := ctx.Match.find(ctx.Tag.Name, names); ok {
+		var value string
+
+		if values := p.Header[key]; len(values) > 0 {
+			value = values[0]
+		}
 
-	if _, ok := p.Header[key]; ok {
-		value := p.Header.Get(name)
 		return &value
 	}
 
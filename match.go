@@ -0,0 +1,104 @@
+package inflate
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// NameMatch defines how the field names are matched with the source keys
+type NameMatch int
+
+const (
+	// MatchDefault keeps the matching of the provider. The converter matches
+	// the exact names.
+	MatchDefault NameMatch = iota
+	// MatchExact matches the equal names
+	MatchExact
+	// MatchFold matches the names case-insensitively
+	MatchFold
+	// MatchNormalize matches the names regardless of their case and their
+	// snake_case, kebab-case or CamelCase form (e.g. user_id and UserID)
+	MatchNormalize
+)
+
+// Match returns true if the name matches the key
+func (m NameMatch) Match(name, key string) bool {
+	switch m {
+	case MatchFold:
+		return strings.EqualFold(name, key)
+	case MatchNormalize:
+		return normalize(name) == normalize(key)
+	default:
+		return name == key
+	}
+}
+
+// or returns the fallback if the strategy is the default one
+func (m NameMatch) or(fallback NameMatch) NameMatch {
+	if m == MatchDefault {
+		return fallback
+	}
+
+	return m
+}
+
+// find returns the key that matches the name. The equal key wins over the
+// others and the rest are tried in sorted order.
+func (m NameMatch) find(name string, keys []string) (string, bool) {
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		if key == name {
+			return key, true
+		}
+	}
+
+	for _, key := range sorted {
+		if m.Match(name, key) {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// index returns the value of the map key that matches the name
+func (m NameMatch) index(source reflect.Value, name string) reflect.Value {
+	if source.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}
+	}
+
+	if value := source.MapIndex(reflect.ValueOf(name).Convert(source.Type().Key())); value.IsValid() {
+		return value
+	}
+
+	if m.or(MatchExact) == MatchExact {
+		return reflect.Value{}
+	}
+
+	var (
+		keys   = source.MapKeys()
+		names  = make([]string, len(keys))
+		lookup = make(map[string]reflect.Value, len(keys))
+	)
+
+	for position, key := range keys {
+		names[position] = key.String()
+		lookup[key.String()] = key
+	}
+
+	if key, ok := m.find(name, names); ok {
+		return source.MapIndex(lookup[key])
+	}
+
+	return reflect.Value{}
+}
+
+// normalize returns the name in lower case without the separators
+func normalize(name string) string {
+	name = strings.ReplaceAll(name, "_", "")
+	name = strings.ReplaceAll(name, "-", "")
+	return strings.ToLower(name)
+}
@@ -0,0 +1,181 @@
+package inflate_test
+
+import (
+	"flag"
+	"io"
+	"net/http"
+	"net/url"
+	"testing/fstest"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NameMatch", func() {
+	DescribeTable("Match",
+		func(match inflate.NameMatch, name, key string, ok bool) {
+			Expect(match.Match(name, key)).To(Equal(ok))
+		},
+		Entry("exact", inflate.MatchExact, "user_id", "user_id", true),
+		Entry("exact with other case", inflate.MatchExact, "user_id", "USER_ID", false),
+		Entry("fold", inflate.MatchFold, "user_id", "USER_ID", true),
+		Entry("fold with other form", inflate.MatchFold, "user_id", "UserID", false),
+		Entry("normalize snake case", inflate.MatchNormalize, "UserID", "user_id", true),
+		Entry("normalize kebab case", inflate.MatchNormalize, "UserID", "user-id", true),
+		Entry("normalize camel case", inflate.MatchNormalize, "user_id", "userId", true),
+		Entry("normalize other name", inflate.MatchNormalize, "user_id", "username", false),
+	)
+
+	Describe("Converter", func() {
+		type User struct {
+			UserID string
+			Name   string `field:"name"`
+		}
+
+		It("matches the exact keys by default", func() {
+			source := map[string]interface{}{"user_id": "1", "name": "root"}
+			target := &User{}
+
+			converter := &inflate.Converter{TagName: "field"}
+			Expect(converter.Convert(&source, target)).To(Succeed())
+			Expect(target).To(Equal(&User{Name: "root"}))
+		})
+
+		It("matches the normalized keys", func() {
+			source := map[string]interface{}{"user_id": "1", "Name": "admin", "name": "root"}
+			target := &User{}
+
+			converter := &inflate.Converter{TagName: "field", Match: inflate.MatchNormalize}
+			Expect(converter.Convert(&source, target)).To(Succeed())
+			Expect(target).To(Equal(&User{UserID: "1", Name: "root"}))
+		})
+
+		It("sets the snake case keys with Set", func() {
+			source := map[string]interface{}{"user_id": "1"}
+			target := &User{}
+
+			Expect(inflate.Set(target, &source)).To(Succeed())
+			Expect(target.UserID).To(Equal("1"))
+		})
+	})
+
+	Describe("Decoder", func() {
+		type Request struct {
+			UserID string `query:"user_id" header:"user_id"`
+		}
+
+		It("keeps the matching of the provider by default", func() {
+			request := &Request{}
+
+			decoder := inflate.NewQueryDecoder(url.Values{"userId": {"1"}})
+			Expect(decoder.Decode(request)).To(Succeed())
+			Expect(request.UserID).To(BeEmpty())
+		})
+
+		It("matches the query keys with the strategy", func() {
+			request := &Request{}
+
+			decoder := inflate.NewQueryDecoder(url.Values{"userId": {"1"}})
+			decoder.Match = inflate.MatchNormalize
+
+			Expect(decoder.Decode(request)).To(Succeed())
+			Expect(request.UserID).To(Equal("1"))
+		})
+
+		It("matches the nested keys with the strategy", func() {
+			type Database struct {
+				MaxConns int `config:"max_conns"`
+			}
+
+			type Settings struct {
+				RequestTimeout int      `config:"request_timeout"`
+				Database       Database `config:"database"`
+			}
+
+			data := map[string]interface{}{
+				"requestTimeout": 5,
+				"database":       map[string]interface{}{"maxConns": 10},
+			}
+
+			decoder := inflate.NewConfigDecoder(data)
+			decoder.Match = inflate.MatchNormalize
+
+			settings := &Settings{}
+			Expect(decoder.Decode(settings)).To(Succeed())
+			Expect(settings.RequestTimeout).To(Equal(5))
+			Expect(settings.Database.MaxConns).To(Equal(10))
+		})
+
+		It("matches the header keys with the strategy", func() {
+			request := &Request{}
+
+			decoder := inflate.NewHeaderDecoder(http.Header{"User-Id": {"1"}})
+			Expect(decoder.Decode(request)).To(Succeed())
+			Expect(request.UserID).To(BeEmpty())
+
+			decoder.Match = inflate.MatchNormalize
+
+			Expect(decoder.Decode(request)).To(Succeed())
+			Expect(request.UserID).To(Equal("1"))
+		})
+
+		It("matches the file names with the strategy", func() {
+			type Database struct {
+				Password string `file:"password,base64"`
+			}
+
+			type Secrets struct {
+				APIKey   string   `file:"api_key"`
+				Database Database `file:"database"`
+			}
+
+			decoder := &inflate.Decoder{
+				TagName: "file",
+				Converter: &inflate.Converter{
+					TagName: "file",
+				},
+				Provider: &inflate.FileTreeProvider{
+					FileSystem: fstest.MapFS{
+						"api-key":           {Data: []byte("secret")},
+						"Database/Password": {Data: []byte("c3dvcmRmaXNo")},
+					},
+				},
+				Match: inflate.MatchNormalize,
+			}
+
+			secrets := &Secrets{}
+			Expect(decoder.Decode(secrets)).To(Succeed())
+			Expect(secrets.APIKey).To(Equal("secret"))
+			Expect(secrets.Database.Password).To(Equal("swordfish"))
+		})
+
+		It("matches the flag names with the strategy", func() {
+			type Options struct {
+				UserID string `flag:"user_id"`
+			}
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			flags.String("user-id", "", "")
+
+			Expect(flags.Parse([]string{"-user-id", "1"})).To(Succeed())
+
+			decoder := &inflate.Decoder{
+				TagName: "flag",
+				Converter: &inflate.Converter{
+					TagName: "flag",
+				},
+				Provider: &inflate.FlagProvider{
+					FlagSet: flags,
+				},
+				Match: inflate.MatchNormalize,
+			}
+
+			options := &Options{}
+			Expect(decoder.Decode(options)).To(Succeed())
+			Expect(options.UserID).To(Equal("1"))
+		})
+	})
+})
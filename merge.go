@@ -0,0 +1,335 @@
+package inflate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// SlicePolicy defines how the slices are merged
+type SlicePolicy int
+
+const (
+	// SliceReplace replaces the target slice with the source one
+	SliceReplace SlicePolicy = iota
+	// SliceAppend appends the source items to the target slice
+	SliceAppend
+)
+
+// MapPolicy defines how the maps are merged
+type MapPolicy int
+
+const (
+	// MapReplace replaces the target map with the source one
+	MapReplace MapPolicy = iota
+	// MapMerge sets the source keys in the target map
+	MapMerge
+)
+
+// MergeOptions represents the merge options
+type MergeOptions struct {
+	Slice SlicePolicy
+	Map   MapPolicy
+}
+
+// Merge copies the non-zero fields of the source to the target. The fields are
+// matched by their field tag or their json tag if it is absent.
+func Merge(target, source interface{}, opts *MergeOptions) error {
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+
+	from, err := check("source", source)
+	if err != nil {
+		return err
+	}
+
+	to, err := check("target", target)
+	if err != nil {
+		return err
+	}
+
+	merger := &merger{
+		options: opts,
+		converter: &Converter{
+			TagName: "field,json",
+		},
+	}
+
+	if kind(from) != reflect.Struct || kind(to) != reflect.Struct {
+		return rerror(from, to, nil)
+	}
+
+	return merger.merge(
+		StructOf(merger.converter.TagName, from).Map(),
+		StructOf(merger.converter.TagName, to),
+	)
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7386) to the target. The
+// null values set the fields to their zero value and delete the map keys.
+func ApplyMergePatch(target interface{}, patch json.RawMessage) error {
+	to, err := check("target", target)
+	if err != nil {
+		return err
+	}
+
+	var document interface{}
+
+	if err := json.Unmarshal(patch, &document); err != nil {
+		return fmt.Errorf("merge patch: %w", err)
+	}
+
+	values, ok := document.(map[string]interface{})
+
+	if !ok || kind(to) != reflect.Struct {
+		return fmt.Errorf("merge patch: document '%s' cannot be applied to %v", patch, kind(to))
+	}
+
+	merger := &merger{
+		options: &MergeOptions{},
+		converter: &Converter{
+			TagName: "field,json",
+		},
+	}
+
+	return merger.patch(values, StructOf(merger.converter.TagName, to))
+}
+
+type merger struct {
+	options   *MergeOptions
+	converter *Converter
+}
+
+func (m *merger) merge(source *Map, target *Struct) error {
+	for _, field := range target.Fields() {
+		value := refer(field.Value)
+
+		if field.Tag.Name == "~" {
+			if kind(value) != reflect.Struct {
+				continue
+			}
+
+			if err := m.merge(source, field.inline(target.TagName, value)); err != nil {
+				return rerrorf(field.Name, err)
+			}
+
+			if err := set(field.Value, value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		item := elem(source.Get(reflect.ValueOf(field.Tag.Name)))
+
+		if !item.IsValid() || item.IsZero() {
+			continue
+		}
+
+		if err := m.mergeField(item, value); err != nil {
+			return rerrorf(field.Tag.Name, err)
+		}
+
+		if err := set(field.Value, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *merger) mergeField(source, target reflect.Value) error {
+	switch kind(target) {
+	case reflect.Slice:
+		if m.options.Slice == SliceAppend {
+			items := reflect.New(target.Type()).Elem()
+
+			if err := m.converter.convert(source, items); err != nil {
+				return err
+			}
+
+			target.Set(reflect.AppendSlice(target, items))
+			return nil
+		}
+	case reflect.Map:
+		if m.options.Map == MapMerge {
+			items := reflect.New(target.Type()).Elem()
+
+			if err := m.converter.convert(source, items); err != nil {
+				return err
+			}
+
+			if target.IsNil() {
+				target.Set(reflect.MakeMap(target.Type()))
+			}
+
+			iter := items.MapRange()
+
+			for iter.Next() {
+				target.SetMapIndex(iter.Key(), iter.Value())
+			}
+
+			return nil
+		}
+	case reflect.Struct:
+		if _, ok := optionalOf(target); ok || convertable(target.Type()) || kind(source) != reflect.Struct {
+			break
+		}
+
+		return m.merge(
+			StructOf(m.converter.TagName, source).Map(),
+			StructOf(m.converter.TagName, target),
+		)
+	}
+
+	return m.converter.convert(source, target)
+}
+
+func (m *merger) patch(values map[string]interface{}, target *Struct) error {
+	for _, field := range target.Fields() {
+		value := m.slot(field.Value)
+
+		if field.Tag.Name == "~" {
+			if kind(value) != reflect.Struct {
+				continue
+			}
+
+			if err := m.patch(values, field.inline(target.TagName, value)); err != nil {
+				return rerrorf(field.Name, err)
+			}
+
+			if err := set(field.Value, value); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		item, ok := values[field.Tag.Name]
+
+		if !ok {
+			continue
+		}
+
+		if item == nil {
+			field.Value.Set(reflect.Zero(field.Value.Type()))
+			continue
+		}
+
+		if err := m.patchField(item, value); err != nil {
+			return rerrorf(field.Tag.Name, err)
+		}
+
+		if err := set(field.Value, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *merger) patchField(item interface{}, target reflect.Value) error {
+	if values, ok := item.(map[string]interface{}); ok {
+		switch kind(target) {
+		case reflect.Struct:
+			if _, ok := optionalOf(target); ok || convertable(target.Type()) {
+				break
+			}
+
+			return m.patch(values, StructOf(m.converter.TagName, target))
+		case reflect.Map:
+			return m.patchMap(values, target)
+		case reflect.Interface:
+			if reflect.TypeOf(values).AssignableTo(target.Type()) {
+				current, _ := target.Interface().(map[string]interface{})
+				target.Set(reflect.ValueOf(mergePatch(current, values)))
+				return nil
+			}
+		}
+
+		// the object replaces the target without its null members
+		item = mergePatch(nil, values)
+	}
+
+	// the values that are not objects replace the target
+	value := reflect.New(target.Type()).Elem()
+
+	if err := m.converter.convert(reflect.ValueOf(item), value); err != nil {
+		return err
+	}
+
+	target.Set(value)
+	return nil
+}
+
+func (m *merger) patchMap(values map[string]interface{}, target reflect.Value) error {
+	if target.IsNil() {
+		target.Set(reflect.MakeMap(target.Type()))
+	}
+
+	for name, item := range values {
+		key := reflect.New(target.Type().Key()).Elem()
+
+		if err := m.converter.convert(reflect.ValueOf(name), key); err != nil {
+			return err
+		}
+
+		if item == nil {
+			target.SetMapIndex(key, reflect.Value{})
+			continue
+		}
+
+		value := reflect.New(target.Type().Elem()).Elem()
+
+		if current := target.MapIndex(key); current.IsValid() {
+			value.Set(current)
+		}
+
+		converted := m.slot(value)
+
+		if err := m.patchField(item, converted); err != nil {
+			return rerrorf(name, err)
+		}
+
+		if err := set(value, converted); err != nil {
+			return err
+		}
+
+		target.SetMapIndex(key, value)
+	}
+
+	return nil
+}
+
+// slot returns the value the patch is applied to. The interfaces are patched
+// as they are since the patch can change the type of their value.
+func (m *merger) slot(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Interface {
+		return value
+	}
+
+	return refer(value)
+}
+
+func mergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(target))
+
+	for key, value := range target {
+		result[key] = value
+	}
+
+	for key, value := range patch {
+		switch item := value.(type) {
+		case nil:
+			delete(result, key)
+		case map[string]interface{}:
+			current, _ := result[key].(map[string]interface{})
+			result[key] = mergePatch(current, item)
+		default:
+			result[key] = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,224 @@
+package inflate_test
+
+import (
+	"encoding/json"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Merge", func() {
+	type Address struct {
+		City    string `field:"city"`
+		Country string `field:"country"`
+	}
+
+	type Profile struct {
+		Name    string                   `field:"name"`
+		Age     int                      `field:"age"`
+		Tags    []string                 `field:"tags"`
+		Labels  map[string]string        `field:"labels"`
+		Address *Address                 `field:"address"`
+		Email   inflate.Optional[string] `field:"email"`
+	}
+
+	type ProfilePatch struct {
+		Name    string                   `field:"name"`
+		Tags    []string                 `field:"tags"`
+		Labels  map[string]string        `field:"labels"`
+		Address Address                  `field:"address"`
+		Email   inflate.Optional[string] `field:"email"`
+	}
+
+	var profile *Profile
+
+	BeforeEach(func() {
+		profile = &Profile{
+			Name:    "John",
+			Age:     30,
+			Tags:    []string{"admin"},
+			Labels:  map[string]string{"env": "prod"},
+			Address: &Address{City: "London", Country: "UK"},
+			Email:   inflate.OptionalOf("john@example.com"),
+		}
+	})
+
+	It("merges the non-zero fields", func() {
+		patch := &ProfilePatch{
+			Name:    "Jack",
+			Tags:    []string{"guest"},
+			Labels:  map[string]string{"team": "api"},
+			Address: Address{City: "Paris"},
+		}
+
+		Expect(inflate.Merge(profile, patch, nil)).To(Succeed())
+		Expect(profile).To(Equal(&Profile{
+			Name:    "Jack",
+			Age:     30,
+			Tags:    []string{"guest"},
+			Labels:  map[string]string{"team": "api"},
+			Address: &Address{City: "Paris", Country: "UK"},
+			Email:   inflate.OptionalOf("john@example.com"),
+		}))
+	})
+
+	It("clears the present but empty optional fields", func() {
+		patch := &ProfilePatch{}
+		patch.Email.Clear()
+
+		Expect(inflate.Merge(profile, patch, nil)).To(Succeed())
+		Expect(profile.Email.IsSet()).To(BeTrue())
+		Expect(profile.Email.Valid()).To(BeFalse())
+		Expect(profile.Name).To(Equal("John"))
+	})
+
+	Context("when the policies merge the collections", func() {
+		It("appends the slices and merges the maps", func() {
+			patch := &ProfilePatch{
+				Tags:   []string{"guest"},
+				Labels: map[string]string{"team": "api"},
+			}
+
+			opts := &inflate.MergeOptions{
+				Slice: inflate.SliceAppend,
+				Map:   inflate.MapMerge,
+			}
+
+			Expect(inflate.Merge(profile, patch, opts)).To(Succeed())
+			Expect(profile.Tags).To(Equal([]string{"admin", "guest"}))
+			Expect(profile.Labels).To(Equal(map[string]string{"env": "prod", "team": "api"}))
+		})
+	})
+
+	Context("when the source is not a struct", func() {
+		It("returns an error", func() {
+			source := 5
+			Expect(inflate.Merge(profile, &source, nil)).To(MatchError("cannot convert int '5' to struct"))
+		})
+	})
+})
+
+var _ = Describe("ApplyMergePatch", func() {
+	type Address struct {
+		City    string `field:"city"`
+		Country string `field:"country"`
+	}
+
+	type Profile struct {
+		Name     string                 `field:"name"`
+		Age      int                    `field:"age"`
+		Tags     []string               `field:"tags"`
+		Labels   map[string]string      `field:"labels"`
+		Address  *Address               `field:"address"`
+		Metadata map[string]interface{} `field:"metadata"`
+	}
+
+	var profile *Profile
+
+	BeforeEach(func() {
+		profile = &Profile{
+			Name:     "John",
+			Age:      30,
+			Tags:     []string{"admin", "guest"},
+			Labels:   map[string]string{"env": "prod", "team": "api"},
+			Address:  &Address{City: "London", Country: "UK"},
+			Metadata: map[string]interface{}{"source": map[string]interface{}{"name": "web", "version": 1.0}},
+		}
+	})
+
+	It("applies the patch successfully", func() {
+		patch := json.RawMessage(`{
+			"name": "Jack",
+			"age": null,
+			"tags": ["owner"],
+			"labels": {"env": null, "zone": "eu"},
+			"address": {"city": "Paris"},
+			"metadata": {"source": {"version": null}}
+		}`)
+
+		Expect(inflate.ApplyMergePatch(profile, patch)).To(Succeed())
+		Expect(profile).To(Equal(&Profile{
+			Name:     "Jack",
+			Tags:     []string{"owner"},
+			Labels:   map[string]string{"team": "api", "zone": "eu"},
+			Address:  &Address{City: "Paris", Country: "UK"},
+			Metadata: map[string]interface{}{"source": map[string]interface{}{"name": "web"}},
+		}))
+	})
+
+	DescribeTable("when the patch is applied to a value of any type",
+		func(original, patch, expected string) {
+			type Document struct {
+				Value interface{} `field:"value"`
+			}
+
+			document := &Document{}
+			Expect(json.Unmarshal([]byte(`{"Value": `+original+`}`), document)).To(Succeed())
+			Expect(inflate.ApplyMergePatch(document, json.RawMessage(`{"value": `+patch+`}`))).To(Succeed())
+
+			data, err := json.Marshal(document.Value)
+			Expect(err).To(BeNil())
+			Expect(data).To(MatchJSON(expected))
+		},
+		Entry("replaces a member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`),
+		Entry("adds a member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`),
+		Entry("removes the only member", `{"a":"b"}`, `{"a":null}`, `{}`),
+		Entry("removes a member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`),
+		Entry("replaces an array with a string", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`),
+		Entry("replaces a string with an array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`),
+		Entry("patches a nested object", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`),
+		Entry("replaces an array of objects", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`),
+		Entry("replaces an array", `["a","b"]`, `["c","d"]`, `["c","d"]`),
+		Entry("replaces an object with an array", `{"a":"b"}`, `["c"]`, `["c"]`),
+		Entry("replaces an object with null", `{"a":"foo"}`, `null`, `null`),
+		Entry("replaces an object with a string", `{"a":"foo"}`, `"bar"`, `"bar"`),
+		Entry("keeps the null members of the target", `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`),
+		Entry("replaces an array with an object", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`),
+		Entry("creates the nested objects", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`),
+	)
+
+	Context("when the map value is not an object", func() {
+		It("removes the null members of the patch", func() {
+			profile.Metadata = map[string]interface{}{"n": "text"}
+
+			Expect(inflate.ApplyMergePatch(profile, json.RawMessage(`{"metadata": {"n": {"q": null, "r": 1}}}`))).To(Succeed())
+			Expect(profile.Metadata).To(Equal(map[string]interface{}{"n": map[string]interface{}{"r": float64(1)}}))
+		})
+	})
+
+	Context("when the patch deletes a nested object", func() {
+		It("sets the field to zero value", func() {
+			Expect(inflate.ApplyMergePatch(profile, json.RawMessage(`{"address": null}`))).To(Succeed())
+			Expect(profile.Address).To(BeNil())
+		})
+	})
+
+	Context("when the target object is nil", func() {
+		It("creates the object", func() {
+			profile.Address = nil
+
+			Expect(inflate.ApplyMergePatch(profile, json.RawMessage(`{"address": {"city": "Paris"}}`))).To(Succeed())
+			Expect(profile.Address).To(Equal(&Address{City: "Paris"}))
+		})
+	})
+
+	Context("when the patch is not an object", func() {
+		It("returns an error", func() {
+			Expect(inflate.ApplyMergePatch(profile, json.RawMessage(`[]`))).To(MatchError("merge patch: document '[]' cannot be applied to struct"))
+		})
+	})
+
+	Context("when the patch is invalid", func() {
+		It("returns an error", func() {
+			Expect(inflate.ApplyMergePatch(profile, json.RawMessage(`{`))).To(MatchError("merge patch: unexpected end of JSON input"))
+		})
+	})
+
+	Context("when the value cannot be converted", func() {
+		It("returns an error", func() {
+			Expect(inflate.ApplyMergePatch(profile, json.RawMessage(`{"age": "old"}`))).To(MatchError(`age: cannot convert string 'old' to int: strconv.ParseInt: parsing "old": invalid syntax`))
+		})
+	})
+})
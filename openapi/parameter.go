@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/phogolabs/inflate"
+)
+
+const (
+	// InPath is the path location
+	InPath = "path"
+	// InQuery is the query location
+	InQuery = "query"
+	// InHeader is the header location
+	InHeader = "header"
+	// InCookie is the cookie location
+	InCookie = "cookie"
+)
+
+var locations = []string{InPath, InQuery, InHeader, InCookie}
+
+var styles = map[string]string{
+	inflate.OptionSimple:         "simple",
+	inflate.OptionForm:           "form",
+	inflate.OptionLabel:          "label",
+	inflate.OptionMatrix:         "matrix",
+	inflate.OptionDeepObject:     "deepObject",
+	inflate.OptionSpaceDelimited: "spaceDelimited",
+	inflate.OptionPipeDelimited:  "pipeDelimited",
+}
+
+// Parameter represents an OpenAPI parameter object
+type Parameter struct {
+	Name     string  `json:"name" yaml:"name"`
+	In       string  `json:"in" yaml:"in"`
+	Required bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Style    string  `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode  bool    `json:"explode" yaml:"explode"`
+	Schema   *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Parameters returns the parameters of the struct's fields tagged with path,
+// query, header or cookie tag
+func Parameters(value interface{}) ([]*Parameter, error) {
+	kind := reflect.TypeOf(value)
+
+	for kind != nil && kind.Kind() == reflect.Ptr {
+		kind = kind.Elem()
+	}
+
+	if kind == nil || kind.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("openapi: type: %v is not a struct", kind)
+	}
+
+	parameters := []*Parameter{}
+
+	for _, location := range locations {
+		if err := parametersOf(kind, location, nil, &parameters); err != nil {
+			return nil, err
+		}
+	}
+
+	return parameters, nil
+}
+
+// parametersOf appends the parameters of the struct's fields in given
+// location. The fields of a struct with prefix option are named by the
+// prefix and their own name.
+func parametersOf(kind reflect.Type, location string, prefix []string, parameters *[]*Parameter) error {
+	for _, field := range fieldsOf(kind, location) {
+		definition := field.StructField(kind)
+
+		if field.Tag.Name == "~" {
+			if item := indirect(definition.Type); item.Kind() == reflect.Struct {
+				if err := parametersOf(item, location, prefix, parameters); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		// the fields without a name in the location's tag are skipped
+		if value, ok := definition.Tag.Lookup(location); !ok || inflate.ParseTag(location, value).Name == "" {
+			continue
+		}
+
+		tag := *field.Tag
+
+		if name, ok := prefixed(definition, &tag); ok {
+			names := append(append([]string{}, prefix...), name)
+
+			if err := parametersOf(indirect(definition.Type), location, names, parameters); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if len(prefix) > 0 {
+			tag.Name = strings.Join(append(append([]string{}, prefix...), tag.Name), ".")
+		}
+
+		parameter, err := parameterOf(definition, &tag)
+		if err != nil {
+			return err
+		}
+
+		*parameters = append(*parameters, parameter)
+	}
+
+	return nil
+}
+
+// fieldsOf returns the fields of the struct type in given location. The nil
+// embedded pointers are allocated so their fields are promoted.
+func fieldsOf(kind reflect.Type, location string) []*inflate.Field {
+	value := reflect.New(kind).Elem()
+
+	for {
+		var (
+			fields    = inflate.StructOf(location, value).Fields()
+			allocated = false
+		)
+
+		for _, field := range fields {
+			if field.Tag.Name != "~" || field.Value.Kind() != reflect.Ptr || !field.Value.IsNil() {
+				continue
+			}
+
+			if field.StructField(kind).Anonymous && field.Value.CanSet() {
+				field.Value.Set(reflect.New(field.Value.Type().Elem()))
+				allocated = true
+			}
+		}
+
+		if !allocated {
+			return fields
+		}
+	}
+}
+
+func parameterOf(field reflect.StructField, tag *inflate.Tag) (*Parameter, error) {
+	schema, err := SchemaOf(field.Type, tag.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := schema.apply(field); err != nil {
+		return nil, fmt.Errorf("openapi: field: '%v' %w", tag.Name, err)
+	}
+
+	parameter := &Parameter{
+		Name:     tag.Name,
+		In:       tag.Key,
+		Required: tag.Key == InPath || schema.required,
+		Schema:   schema,
+	}
+
+	for _, option := range tag.Options {
+		if style, ok := styles[strings.ToLower(option)]; ok {
+			parameter.Style = style
+			parameter.Explode = tag.HasOption(inflate.OptionExplode)
+			break
+		}
+	}
+
+	// the styles used by the providers when the tag does not have options
+	if parameter.Style == "" {
+		switch tag.Key {
+		case InPath, InHeader:
+			parameter.Style = styles[inflate.OptionSimple]
+		case InQuery:
+			parameter.Style = styles[inflate.OptionForm]
+			parameter.Explode = true
+		case InCookie:
+			parameter.Style = styles[inflate.OptionForm]
+			parameter.Explode = schema.Type != TypeArray && schema.Type != TypeObject
+		}
+	}
+
+	return parameter, nil
+}
+
+// prefixed returns the prefix of a struct field with prefix option
+func prefixed(field reflect.StructField, tag *inflate.Tag) (string, bool) {
+	prefix, ok := tag.Lookup(inflate.OptionPrefix)
+
+	if !ok && !tag.HasOption(inflate.OptionPrefix) {
+		return "", false
+	}
+
+	item := indirect(field.Type)
+
+	if item.Kind() != reflect.Struct || item == timeType || reflect.PtrTo(item).Implements(textUnmarshalerType) {
+		return "", false
+	}
+
+	if prefix == "" {
+		prefix = tag.Name
+	}
+
+	return prefix, true
+}
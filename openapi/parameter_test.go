@@ -0,0 +1,150 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/phogolabs/inflate"
+	"github.com/phogolabs/inflate/openapi"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parameters", func() {
+	type Filter struct {
+		Name  string   `query:"name"`
+		Roles []string `query:"role"`
+	}
+
+	type Paging struct {
+		Page  int `query:"page" default:"1" validate:"min=1"`
+		Limit int `query:"limit" default:"20" validate:"min=1,max=100"`
+	}
+
+	type Request struct {
+		ID        string                      `path:"id"`
+		Tags      []string                    `path:"tags,label,explode"`
+		Order     string                      `query:"order" default:"asc" validate:"enum=asc|desc"`
+		Filter    Filter                      `query:"filter,deep-object"`
+		Since     inflate.Optional[time.Time] `query:"since"`
+		Paging    *Paging                     `query:"~"`
+		RequestID string                      `header:"X-Request-ID" validate:"required,minlength=8"`
+		Session   string                      `cookie:"session"`
+		Ignored   string
+	}
+
+	It("returns the parameters successfully", func() {
+		parameters, err := openapi.Parameters(&Request{})
+		Expect(err).To(BeNil())
+
+		data, err := json.Marshal(parameters)
+		Expect(err).To(BeNil())
+		Expect(data).To(MatchJSON(`[
+			{"name": "id", "in": "path", "required": true, "style": "simple", "explode": false, "schema": {"type": "string"}},
+			{"name": "tags", "in": "path", "required": true, "style": "label", "explode": true, "schema": {"type": "array", "items": {"type": "string"}}},
+			{"name": "order", "in": "query", "style": "form", "explode": true, "schema": {"type": "string", "default": "asc", "enum": ["asc", "desc"]}},
+			{"name": "filter", "in": "query", "style": "deepObject", "explode": false, "schema": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"role": {"type": "array", "items": {"type": "string"}}
+				}
+			}},
+			{"name": "since", "in": "query", "style": "form", "explode": true, "schema": {"type": "string", "format": "date-time"}},
+			{"name": "page", "in": "query", "style": "form", "explode": true, "schema": {"type": "integer", "format": "int64", "default": 1, "minimum": 1}},
+			{"name": "limit", "in": "query", "style": "form", "explode": true, "schema": {"type": "integer", "format": "int64", "default": 20, "minimum": 1, "maximum": 100}},
+			{"name": "X-Request-ID", "in": "header", "required": true, "style": "simple", "explode": false, "schema": {"type": "string", "minLength": 8}},
+			{"name": "session", "in": "cookie", "style": "form", "explode": true, "schema": {"type": "string"}}
+		]`))
+	})
+
+	It("returns parameters that can be serialized as YAML", func() {
+		parameters, err := openapi.Parameters(&Request{})
+		Expect(err).To(BeNil())
+
+		data, err := yaml.Marshal(parameters[0])
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal("name: id\nin: path\nrequired: true\nstyle: simple\nexplode: false\nschema:\n    type: string\n"))
+	})
+
+	Context("when the default value cannot be converted", func() {
+		type Request struct {
+			Page int `query:"page" default:"first"`
+		}
+
+		It("returns an error", func() {
+			parameters, err := openapi.Parameters(&Request{})
+			Expect(err).To(MatchError(`openapi: field: 'page' default: cannot convert string 'first' to int: strconv.ParseInt: parsing "first": invalid syntax`))
+			Expect(parameters).To(BeNil())
+		})
+	})
+
+	Context("when the rule is unknown", func() {
+		type Request struct {
+			Page int `query:"page" validate:"even"`
+		}
+
+		It("returns an error", func() {
+			parameters, err := openapi.Parameters(&Request{})
+			Expect(err).To(MatchError("openapi: field: 'page' rule: [even] not supported: unknown rule"))
+			Expect(parameters).To(BeNil())
+		})
+	})
+
+	Context("when the struct embeds other structs", func() {
+		type Paging struct {
+			Page  int `query:"page"`
+			Limit int `query:"limit"`
+		}
+
+		type Sorting struct {
+			Limit int `query:"limit"`
+			Sort  int `query:"sort"`
+		}
+
+		type Request struct {
+			*Paging
+			Sorting
+			Page string `query:"page"`
+		}
+
+		It("returns the dominant fields of the embedded structs", func() {
+			parameters, err := openapi.Parameters(&Request{})
+			Expect(err).To(BeNil())
+			Expect(parameters).To(HaveLen(2))
+			Expect(parameters[0].Name).To(Equal("sort"))
+			Expect(parameters[1].Name).To(Equal("page"))
+			Expect(parameters[1].Schema.Type).To(Equal(openapi.TypeString))
+		})
+	})
+
+	Context("when the struct field has a prefix", func() {
+		type Page struct {
+			Size   int `query:"size"`
+			Cursor int `query:"cursor" header:"X-Cursor"`
+		}
+
+		type Request struct {
+			Page Page `query:"p,prefix"`
+		}
+
+		It("returns the parameters with the namespaced names", func() {
+			parameters, err := openapi.Parameters(&Request{})
+			Expect(err).To(BeNil())
+			Expect(parameters).To(HaveLen(2))
+			Expect(parameters[0].Name).To(Equal("p.size"))
+			Expect(parameters[1].Name).To(Equal("p.cursor"))
+			Expect(parameters[1].In).To(Equal(openapi.InQuery))
+		})
+	})
+
+	Context("when the type is not a struct", func() {
+		It("returns an error", func() {
+			parameters, err := openapi.Parameters(5)
+			Expect(err).To(MatchError("openapi: type: int is not a struct"))
+			Expect(parameters).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,321 @@
+package openapi
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phogolabs/inflate"
+)
+
+const (
+	// TypeString is the string type
+	TypeString = "string"
+	// TypeInteger is the integer type
+	TypeInteger = "integer"
+	// TypeNumber is the number type
+	TypeNumber = "number"
+	// TypeBoolean is the boolean type
+	TypeBoolean = "boolean"
+	// TypeArray is the array type
+	TypeArray = "array"
+	// TypeObject is the object type
+	TypeObject = "object"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf(new(encoding.TextUnmarshaler)).Elem()
+	valuerType          = reflect.TypeOf(new(driver.Valuer)).Elem()
+	scannerType         = reflect.TypeOf(new(sql.Scanner)).Elem()
+)
+
+// Schema represents an OpenAPI schema object
+type Schema struct {
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Default              interface{}        `json:"default,omitempty" yaml:"default,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	required             bool
+	kind                 reflect.Type
+}
+
+// SchemaOf returns the schema of given type. The properties of the structs are
+// named by the tag.
+func SchemaOf(kind reflect.Type, tagName string) (*Schema, error) {
+	kind = indirect(kind)
+
+	schema := &Schema{
+		kind: kind,
+	}
+
+	switch {
+	case kind == timeType:
+		schema.Type = TypeString
+		schema.Format = "date-time"
+		return schema, nil
+	case reflect.PtrTo(kind).Implements(textUnmarshalerType):
+		schema.Type = TypeString
+		return schema, nil
+	case kind.Kind() == reflect.Struct && (reflect.PtrTo(kind).Implements(valuerType) || reflect.PtrTo(kind).Implements(scannerType)):
+		// the sql.Null types have the schema of their value
+		if field, ok := nullable(kind); ok {
+			return SchemaOf(field.Type, tagName)
+		}
+
+		schema.Type = TypeString
+		return schema, nil
+	}
+
+	switch kind.Kind() {
+	case reflect.Bool:
+		schema.Type = TypeBoolean
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		schema.Type = TypeInteger
+		schema.Format = "int32"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		schema.Type = TypeInteger
+		schema.Format = "int64"
+	case reflect.Float32:
+		schema.Type = TypeNumber
+		schema.Format = "float"
+	case reflect.Float64:
+		schema.Type = TypeNumber
+		schema.Format = "double"
+	case reflect.String:
+		schema.Type = TypeString
+	case reflect.Array, reflect.Slice:
+		if kind.Elem().Kind() == reflect.Uint8 {
+			schema.Type = TypeString
+			schema.Format = "byte"
+			break
+		}
+
+		items, err := SchemaOf(kind.Elem(), tagName)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Type = TypeArray
+		schema.Items = items
+	case reflect.Map:
+		properties, err := SchemaOf(kind.Elem(), tagName)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Type = TypeObject
+		schema.AdditionalProperties = properties
+	case reflect.Struct:
+		schema.Type = TypeObject
+		schema.Properties = make(map[string]*Schema)
+
+		if err := schema.properties(kind, tagName); err != nil {
+			return nil, err
+		}
+	case reflect.Interface:
+	default:
+		return nil, fmt.Errorf("openapi: type: %v not supported", kind)
+	}
+
+	return schema, nil
+}
+
+func (s *Schema) properties(kind reflect.Type, tagName string) error {
+	value := reflect.New(kind).Elem()
+
+	for _, field := range inflate.StructOf(tagName, value).Fields() {
+		definition := field.StructField(kind)
+
+		if field.Tag.Name == "~" {
+			if item := indirect(definition.Type); item.Kind() == reflect.Struct {
+				if err := s.properties(item, tagName); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		property, err := SchemaOf(definition.Type, tagName)
+		if err != nil {
+			return err
+		}
+
+		if err := property.apply(definition); err != nil {
+			return fmt.Errorf("openapi: field: '%v' %w", field.Tag.Name, err)
+		}
+
+		s.Properties[field.Tag.Name] = property
+	}
+
+	return nil
+}
+
+// apply sets the default value and the validation rules of the field
+func (s *Schema) apply(field reflect.StructField) error {
+	if value := field.Tag.Get("default"); value != "" && !strings.HasPrefix(value, "$") {
+		item, err := s.valueOf(value)
+		if err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+
+		s.Default = item
+	}
+
+	rules := field.Tag.Get("validate")
+
+	if rules == "" {
+		return nil
+	}
+
+	for _, rule := range inflate.ParseRules(rules) {
+		if err := s.rule(rule.Name, rule.Arg); err != nil {
+			return fmt.Errorf("rule: [%v] not supported: %w", rule, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) rule(name, arg string) error {
+	switch name {
+	case inflate.RuleRequired:
+		s.required = true
+	case inflate.RuleMin, inflate.RuleMax:
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return err
+		}
+
+		if name == inflate.RuleMin {
+			s.Minimum = &limit
+		} else {
+			s.Maximum = &limit
+		}
+	case inflate.RulePattern:
+		s.Pattern = arg
+	case inflate.RuleEnum:
+		for _, item := range strings.Split(arg, "|") {
+			value, err := s.valueOf(item)
+			if err != nil {
+				return err
+			}
+
+			s.Enum = append(s.Enum, value)
+		}
+	case inflate.RuleMinLength, inflate.RuleMaxLength, inflate.RuleMinItems, inflate.RuleMaxItems:
+		limit, err := strconv.Atoi(arg)
+		if err != nil {
+			return err
+		}
+
+		switch name {
+		case inflate.RuleMinLength:
+			s.MinLength = &limit
+		case inflate.RuleMaxLength:
+			s.MaxLength = &limit
+		case inflate.RuleMinItems:
+			s.MinItems = &limit
+		case inflate.RuleMaxItems:
+			s.MaxItems = &limit
+		}
+	case inflate.RuleUniqueItems:
+		s.UniqueItems = true
+	default:
+		return fmt.Errorf("unknown rule")
+	}
+
+	return nil
+}
+
+// valueOf converts the tag value to the schema's type
+func (s *Schema) valueOf(text string) (interface{}, error) {
+	switch s.Type {
+	case TypeArray, TypeObject:
+		var value interface{}
+
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case "":
+		return text, nil
+	}
+
+	var (
+		value     = reflect.New(s.kind)
+		converter = &inflate.Converter{
+			TagName: "default",
+		}
+	)
+
+	if err := converter.Convert(&text, value.Interface()); err != nil {
+		return nil, err
+	}
+
+	return value.Elem().Interface(), nil
+}
+
+type optional interface {
+	IsSet() bool
+	Valid() bool
+}
+
+var optionalType = reflect.TypeOf(new(optional)).Elem()
+
+// nullable returns the value field of a struct like sql.NullString that has
+// a Valid field and a value field
+func nullable(kind reflect.Type) (reflect.StructField, bool) {
+	valid, ok := kind.FieldByName("Valid")
+
+	if !ok || valid.Type.Kind() != reflect.Bool || kind.NumField() != 2 {
+		return reflect.StructField{}, false
+	}
+
+	for index := 0; index < kind.NumField(); index++ {
+		if field := kind.Field(index); field.Name != valid.Name {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// indirect returns the type of the pointers and the optional values
+func indirect(kind reflect.Type) reflect.Type {
+	for {
+		switch {
+		case kind.Kind() == reflect.Ptr:
+			kind = kind.Elem()
+		case kind.Kind() == reflect.Struct && kind.Implements(optionalType):
+			method, ok := kind.MethodByName("Value")
+
+			if !ok || method.Type.NumOut() != 1 {
+				return kind
+			}
+
+			kind = method.Type.Out(0)
+		default:
+			return kind
+		}
+	}
+}
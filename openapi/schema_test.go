@@ -0,0 +1,80 @@
+package openapi_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+
+	"github.com/phogolabs/inflate/openapi"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchemaOf", func() {
+	It("returns the schema of the basic types", func() {
+		for value, expected := range map[interface{}][2]string{
+			true:       {"boolean", ""},
+			int32(1):   {"integer", "int32"},
+			int64(1):   {"integer", "int64"},
+			float32(1): {"number", "float"},
+			float64(1): {"number", "double"},
+			"text":     {"string", ""},
+		} {
+			schema, err := openapi.SchemaOf(reflect.TypeOf(value), "json")
+			Expect(err).To(BeNil())
+			Expect(schema.Type).To(Equal(expected[0]))
+			Expect(schema.Format).To(Equal(expected[1]))
+		}
+	})
+
+	It("returns the schema of a map", func() {
+		schema, err := openapi.SchemaOf(reflect.TypeOf(map[string]int{}), "json")
+		Expect(err).To(BeNil())
+		Expect(schema.Type).To(Equal(openapi.TypeObject))
+		Expect(schema.AdditionalProperties.Type).To(Equal(openapi.TypeInteger))
+	})
+
+	It("returns the schema of a byte slice", func() {
+		schema, err := openapi.SchemaOf(reflect.TypeOf([]byte{}), "json")
+		Expect(err).To(BeNil())
+		Expect(schema.Type).To(Equal(openapi.TypeString))
+		Expect(schema.Format).To(Equal("byte"))
+	})
+
+	It("returns the schema of the sql null types", func() {
+		for value, expected := range map[interface{}][2]string{
+			sql.NullBool{}:          {"boolean", ""},
+			sql.NullInt32{}:         {"integer", "int32"},
+			sql.NullInt64{}:         {"integer", "int64"},
+			sql.NullFloat64{}:       {"number", "double"},
+			sql.NullString{}:        {"string", ""},
+			sql.NullTime{}:          {"string", "date-time"},
+			sql.Null[int64]{}:       {"integer", "int64"},
+			sql.Null[json.Number]{}: {"string", ""},
+		} {
+			schema, err := openapi.SchemaOf(reflect.TypeOf(value), "json")
+			Expect(err).To(BeNil())
+			Expect(schema.Type).To(Equal(expected[0]))
+			Expect(schema.Format).To(Equal(expected[1]))
+		}
+	})
+
+	It("returns the pattern with a quantifier", func() {
+		type Country struct {
+			Code string `json:"code" validate:"required,pattern=^[A-Z]{2,3}$"`
+		}
+
+		schema, err := openapi.SchemaOf(reflect.TypeOf(Country{}), "json")
+		Expect(err).To(BeNil())
+		Expect(schema.Properties["code"].Pattern).To(Equal("^[A-Z]{2,3}$"))
+	})
+
+	Context("when the type is not supported", func() {
+		It("returns an error", func() {
+			schema, err := openapi.SchemaOf(reflect.TypeOf(func() {}), "json")
+			Expect(err).To(MatchError("openapi: type: func() not supported"))
+			Expect(schema).To(BeNil())
+		})
+	})
+})
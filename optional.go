@@ -0,0 +1,104 @@
+package inflate
+
+import "reflect"
+
+// Optional represents a value that might be absent. The decoders set the value
+// only if it is present in the source. A present but empty value (e.g. ?limit=)
+// is set as invalid.
+type Optional[T any] struct {
+	value   T
+	present bool
+	valid   bool
+}
+
+// OptionalOf returns a present and valid optional value
+func OptionalOf[T any](value T) Optional[T] {
+	return Optional[T]{
+		value:   value,
+		present: true,
+		valid:   true,
+	}
+}
+
+// Set sets the value
+func (o *Optional[T]) Set(value T) {
+	o.value = value
+	o.present = true
+	o.valid = true
+}
+
+// Clear marks the value as present but empty
+func (o *Optional[T]) Clear() {
+	var zero T
+
+	o.value = zero
+	o.present = true
+	o.valid = false
+}
+
+// IsSet returns true if the value is present
+func (o Optional[T]) IsSet() bool {
+	return o.present
+}
+
+// Valid returns true if the value is present and not empty
+func (o Optional[T]) Valid() bool {
+	return o.valid
+}
+
+// Value returns the value
+func (o Optional[T]) Value() T {
+	return o.value
+}
+
+func (o Optional[T]) optional() (reflect.Value, bool, bool) {
+	return reflect.ValueOf(&o.value).Elem(), o.present, o.valid
+}
+
+func (o *Optional[T]) optionalType() reflect.Type {
+	return reflect.TypeOf(&o.value).Elem()
+}
+
+func (o *Optional[T]) setOptional(value reflect.Value) {
+	if !value.IsValid() {
+		o.Clear()
+		return
+	}
+
+	o.Set(value.Interface().(T))
+}
+
+type optionalGetter interface {
+	optional() (value reflect.Value, present bool, valid bool)
+}
+
+type optionalSetter interface {
+	optionalType() reflect.Type
+	setOptional(value reflect.Value)
+}
+
+var optionalSetterType = reflect.TypeOf((*optionalSetter)(nil)).Elem()
+
+// isOptional returns true if the type is an optional. Unlike optionalOf it
+// does not need an addressable value.
+func isOptional(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(optionalSetterType)
+}
+
+func optionalOf(target reflect.Value) (optionalSetter, bool) {
+	if target.Kind() != reflect.Struct || !target.CanAddr() {
+		return nil, false
+	}
+
+	setter, ok := target.Addr().Interface().(optionalSetter)
+	return setter, ok
+}
+
+func optionalValueOf(source reflect.Value) (optionalGetter, bool) {
+	if source.Kind() != reflect.Struct || !source.CanInterface() {
+		return nil, false
+	}
+
+	getter, ok := source.Interface().(optionalGetter)
+	return getter, ok
+}
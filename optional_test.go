@@ -0,0 +1,113 @@
+package inflate_test
+
+import (
+	"database/sql"
+	"net/url"
+
+	"github.com/phogolabs/inflate"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Optional", func() {
+	It("sets the value", func() {
+		value := inflate.Optional[int]{}
+		Expect(value.IsSet()).To(BeFalse())
+		Expect(value.Valid()).To(BeFalse())
+
+		value.Set(5)
+		Expect(value.IsSet()).To(BeTrue())
+		Expect(value.Valid()).To(BeTrue())
+		Expect(value.Value()).To(Equal(5))
+
+		value.Clear()
+		Expect(value.IsSet()).To(BeTrue())
+		Expect(value.Valid()).To(BeFalse())
+		Expect(value.Value()).To(Equal(0))
+	})
+
+	Describe("Decoder", func() {
+		type Filter struct {
+			Limit  inflate.Optional[int]      `query:"limit"`
+			Name   inflate.Optional[string]   `query:"name"`
+			Tags   inflate.Optional[[]string] `query:"tags"`
+			Owner  inflate.Optional[*string]  `query:"owner"`
+			Offset sql.NullInt64              `query:"offset"`
+		}
+
+		It("decodes the presence of the values", func() {
+			query, err := url.ParseQuery("limit=&tags=a&tags=b&owner=root&offset=")
+			Expect(err).To(BeNil())
+
+			filter := &Filter{}
+			Expect(inflate.NewQueryDecoder(query).Decode(filter)).To(Succeed())
+
+			Expect(filter.Limit.IsSet()).To(BeTrue())
+			Expect(filter.Limit.Valid()).To(BeFalse())
+
+			Expect(filter.Name.IsSet()).To(BeFalse())
+			Expect(filter.Name.Valid()).To(BeFalse())
+
+			Expect(filter.Tags.IsSet()).To(BeTrue())
+			Expect(filter.Tags.Valid()).To(BeTrue())
+			Expect(filter.Tags.Value()).To(Equal([]string{"a", "b"}))
+
+			Expect(filter.Owner.Valid()).To(BeTrue())
+			Expect(*filter.Owner.Value()).To(Equal("root"))
+
+			Expect(filter.Offset.Valid).To(BeFalse())
+		})
+
+		Context("when the value cannot be converted", func() {
+			It("returns an error", func() {
+				query, err := url.ParseQuery("limit=five")
+				Expect(err).To(BeNil())
+
+				filter := &Filter{}
+				Expect(inflate.NewQueryDecoder(query).Decode(filter)).To(MatchError(`cannot convert string 'five' to int: strconv.ParseInt: parsing "five": invalid syntax`))
+				Expect(filter.Limit.IsSet()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Converter", func() {
+		type Patch struct {
+			Name  inflate.Optional[string] `field:"name"`
+			Age   inflate.Optional[int64]  `field:"age"`
+			Email inflate.Optional[string] `field:"email"`
+		}
+
+		type User struct {
+			Name  string `field:"name"`
+			Age   int    `field:"age"`
+			Email string `field:"email"`
+		}
+
+		It("converts the optional values to values", func() {
+			patch := &Patch{
+				Name: inflate.OptionalOf("Jack"),
+				Age:  inflate.OptionalOf(int64(30)),
+			}
+
+			patch.Email.Clear()
+
+			user := &User{Name: "John", Email: "john@example.com"}
+			Expect(inflate.Set(user, patch)).To(Succeed())
+			Expect(user.Name).To(Equal("Jack"))
+			Expect(user.Age).To(Equal(30))
+			Expect(user.Email).To(BeEmpty())
+		})
+
+		It("converts the values to optional values", func() {
+			user := &User{Name: "Jack", Age: 30}
+			patch := &Patch{}
+
+			Expect(inflate.Set(patch, user)).To(Succeed())
+			Expect(patch.Name.Value()).To(Equal("Jack"))
+			Expect(patch.Age.Value()).To(Equal(int64(30)))
+			Expect(patch.Email.IsSet()).To(BeTrue())
+			Expect(patch.Email.Valid()).To(BeFalse())
+		})
+	})
+})
@@ -18,9 +18,14 @@ func NewPathDecoder(r *chi.RouteParams) *Decoder {
 		Provider: &PathProvider{
 			Param: r,
 		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
 	}
 }
 
+var _ ValueChecker = &PathProvider{}
+
 var _ ValueProvider = &PathProvider{}
 
 // PathProvider represents a parameter provider that fetches values from
@@ -58,8 +63,33 @@ func (p *PathProvider) Value(ctx *Context) (interface{}, error) {
 	}
 }
 
+// Check checks whether the options are supported for the field's type
+func (p *PathProvider) Check(ctx *Context) error {
+	if ctx.Tag.Name == "" {
+		return nil
+	}
+
+	if len(ctx.Tag.Options) == 0 {
+		return nil
+	}
+
+	switch {
+	case ctx.Tag.HasOption(OptionSimple):
+	case ctx.Tag.HasOption(OptionLabel):
+	case ctx.Tag.HasOption(OptionMatrix):
+	default:
+		return p.notProvided(ctx,
+			OptionSimple,
+			OptionLabel,
+			OptionMatrix,
+		)
+	}
+
+	return nil
+}
+
 func (p *PathProvider) valueOf(ctx *Context) (interface{}, error) {
-	param := p.param(ctx.Tag.Name)
+	param := p.param(ctx)
 
 	if param == nil {
 		return nil, nil
@@ -84,7 +114,7 @@ func (p *PathProvider) valueOf(ctx *Context) (interface{}, error) {
 }
 
 func (p *PathProvider) arrayOf(ctx *Context) ([]interface{}, error) {
-	param := p.param(ctx.Tag.Name)
+	param := p.param(ctx)
 
 	if param == nil {
 		return nil, nil
@@ -134,7 +164,7 @@ func (p *PathProvider) arrayOf(ctx *Context) ([]interface{}, error) {
 }
 
 func (p *PathProvider) mapOf(ctx *Context) (m map[string]interface{}, err error) {
-	param := p.param(ctx.Tag.Name)
+	param := p.param(ctx)
 
 	if param == nil {
 		return nil, nil
@@ -189,9 +219,15 @@ func (p *PathProvider) mapOf(ctx *Context) (m map[string]interface{}, err error)
 	return m, nil
 }
 
-func (p *PathProvider) param(name string) *string {
+func (p *PathProvider) param(ctx *Context) *string {
+	key, ok := ctx.Match.or(MatchFold).find(ctx.Tag.Name, p.Param.Keys)
+
+	if !ok {
+		return nil
+	}
+
 	for index, k := range p.Param.Keys {
-		if strings.EqualFold(k, name) {
+		if k == key {
 			return &p.Param.Values[index]
 		}
 	}
@@ -19,6 +19,9 @@ func NewQueryDecoder(query url.Values) *Decoder {
 		Provider: &QueryProvider{
 			Query: query,
 		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
 	}
 }
 
@@ -32,15 +35,38 @@ func NewFormDecoder(query url.Values) *Decoder {
 		Provider: &QueryProvider{
 			Query: query,
 		},
+		Validator: &RuleValidator{
+			TagName: "validate",
+		},
 	}
 }
 
+var _ KeyJoiner = &QueryProvider{}
+
+var _ ValueChecker = &QueryProvider{}
+
 var _ ValueProvider = &QueryProvider{}
 
 // QueryProvider represents a parameter provider that fetches values from
 // incoming request's cookies
 type QueryProvider struct {
 	Query url.Values
+	// Separator joins the names of the prefixed fields (default: ".")
+	Separator string
+}
+
+// JoinKey joins the names of a prefixed field. The values of the joined key
+// are also read from the key in bracket notation (e.g. page[size]).
+func (p *QueryProvider) JoinKey(names ...string) string {
+	return strings.Join(names, p.separator())
+}
+
+func (p *QueryProvider) separator() string {
+	if p.Separator == "" {
+		return "."
+	}
+
+	return p.Separator
 }
 
 // Value returns a primitive value
@@ -73,8 +99,73 @@ func (p *QueryProvider) Value(ctx *Context) (interface{}, error) {
 	}
 }
 
+// Check checks whether the options are supported for the field's type
+func (p *QueryProvider) Check(ctx *Context) error {
+	if ctx.Tag.Name == "" {
+		return nil
+	}
+
+	if len(ctx.Tag.Options) == 0 {
+		ctx.Tag.AddOption(OptionForm)
+		ctx.Tag.AddOption(OptionExplode)
+	}
+
+	switch shape(ctx.Type) {
+	case reflect.Map:
+		switch {
+		case ctx.Tag.HasOption(OptionForm):
+		case ctx.Tag.HasOption(OptionSpaceDelimited):
+			return p.notSupported(ctx, OptionSpaceDelimited)
+		case ctx.Tag.HasOption(OptionPipeDelimited):
+			return p.notSupported(ctx, OptionPipeDelimited)
+		case ctx.Tag.HasOption(OptionDeepObject):
+			if ctx.Tag.HasOption(OptionExplode) {
+				return p.notSupported(ctx, OptionExplode)
+			}
+		default:
+			return p.notProvided(ctx,
+				OptionForm,
+				OptionSpaceDelimited,
+				OptionPipeDelimited,
+			)
+		}
+	case reflect.Slice:
+		switch {
+		case ctx.Tag.HasOption(OptionForm):
+		case ctx.Tag.HasOption(OptionSpaceDelimited):
+		case ctx.Tag.HasOption(OptionPipeDelimited):
+		case ctx.Tag.HasOption(OptionDeepObject):
+			return p.notSupported(ctx, OptionDeepObject)
+		default:
+			return p.notProvided(ctx,
+				OptionForm,
+				OptionSpaceDelimited,
+				OptionPipeDelimited,
+			)
+		}
+	default:
+		switch {
+		case ctx.Tag.HasOption(OptionForm):
+		case ctx.Tag.HasOption(OptionSpaceDelimited):
+			return p.notSupported(ctx, OptionSpaceDelimited)
+		case ctx.Tag.HasOption(OptionPipeDelimited):
+			return p.notSupported(ctx, OptionPipeDelimited)
+		case ctx.Tag.HasOption(OptionDeepObject):
+			return p.notSupported(ctx, OptionDeepObject)
+		default:
+			return p.notProvided(ctx,
+				OptionForm,
+				OptionSpaceDelimited,
+				OptionDeepObject,
+			)
+		}
+	}
+
+	return nil
+}
+
 func (p *QueryProvider) valueOf(ctx *Context) (interface{}, error) {
-	values := p.queryArray(ctx.Tag.Name)
+	values := p.queryArray(ctx)
 	if values == nil || len(values) == 0 {
 		return nil, nil
 	}
@@ -98,7 +189,7 @@ func (p *QueryProvider) valueOf(ctx *Context) (interface{}, error) {
 }
 
 func (p *QueryProvider) arrayOf(ctx *Context) ([]interface{}, error) {
-	values := p.queryArray(ctx.Tag.Name)
+	values := p.queryArray(ctx)
 
 	if values == nil || len(values) == 0 {
 		return nil, nil
@@ -137,7 +228,7 @@ func (p *QueryProvider) mapOf(ctx *Context) (map[string]interface{}, error) {
 			return p.queryMap(), nil
 		}
 
-		values := p.queryArray(ctx.Tag.Name)
+		values := p.queryArray(ctx)
 
 		if values == nil || len(values) == 0 {
 			return nil, nil
@@ -200,11 +291,34 @@ func (p *QueryProvider) deepObject(ctx *Context) (map[string]interface{}, error)
 	return result, nil
 }
 
-func (p *QueryProvider) queryArray(key string) []string {
+func (p *QueryProvider) queryArray(ctx *Context) []string {
+	key := ctx.Tag.Name
+
 	if values, ok := p.Query[key]; ok {
 		return values
 	}
 
+	if separator := p.separator(); strings.Contains(key, separator) {
+		parts := strings.Split(key, separator)
+		key = parts[0] + "[" + strings.Join(parts[1:], "][") + "]"
+
+		if values, ok := p.Query[key]; ok {
+			return values
+		}
+	}
+
+	if match := ctx.Match.or(MatchExact); match != MatchExact {
+		names := make([]string, 0, len(p.Query))
+
+		for name := range p.Query {
+			names = append(names, name)
+		}
+
+		if key, ok := match.find(ctx.Tag.Name, names); ok {
+			return p.Query[key]
+		}
+	}
+
 	return nil
 }
 
@@ -36,6 +36,34 @@ var _ = Describe("Query", func() {
 			decoder := inflate.NewQueryDecoder(url.Values{})
 			Expect(decoder).NotTo(BeNil())
 		})
+
+		Context("when the struct field has a prefix", func() {
+			type Pagination struct {
+				Size   int `query:"size"`
+				Number int `query:"number"`
+			}
+
+			type Filter struct {
+				Page   Pagination `query:"page,prefix"`
+				Cursor Pagination `query:"cursor,prefix=c"`
+			}
+
+			It("decodes the fields from the prefixed keys", func() {
+				query := url.Values{}
+				query.Set("page.size", "10")
+				query.Set("page[number]", "2")
+				query.Set("c.size", "5")
+
+				filter := &Filter{}
+				Expect(inflate.NewQueryDecoder(query).Decode(filter)).To(Succeed())
+				Expect(filter.Page).To(Equal(Pagination{Size: 10, Number: 2}))
+				Expect(filter.Cursor).To(Equal(Pagination{Size: 5}))
+			})
+
+			It("checks the options of the nested fields", func() {
+				Expect(inflate.Check(&Filter{}, "query")).To(Succeed())
+			})
+		})
 	})
 
 	Describe("NewFormDecoder", func() {
@@ -0,0 +1,299 @@
+package inflate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// RuleRequired is the required rule
+	RuleRequired = "required"
+	// RuleMin is the minimum rule
+	RuleMin = "min"
+	// RuleMax is the maximum rule
+	RuleMax = "max"
+	// RulePattern is the pattern rule
+	RulePattern = "pattern"
+	// RuleEnum is the enum rule
+	RuleEnum = "enum"
+	// RuleMinLength is the min length rule
+	RuleMinLength = "minlength"
+	// RuleMaxLength is the max length rule
+	RuleMaxLength = "maxlength"
+	// RuleMinItems is the min items rule
+	RuleMinItems = "minitems"
+	// RuleMaxItems is the max items rule
+	RuleMaxItems = "maxitems"
+	// RuleUniqueItems is the unique items rule
+	RuleUniqueItems = "uniqueitems"
+)
+
+//go:generate counterfeiter -fake-name ValueValidator -o ./fake/value_validator.go . ValueValidator
+
+// ValueValidator validates a converted value. The value is nil if the provider
+// does not have a value for the field.
+type ValueValidator interface {
+	Validate(ctx *Context, value interface{}) error
+}
+
+var patterns sync.Map
+
+var _ ValueValidator = &RuleValidator{}
+
+// RuleValidator validates the values against the rules defined in the field's
+// tag (e.g. validate:"required,min=1,max=100,enum=asc|desc"). The rules follow
+// the OpenAPI schema keywords.
+type RuleValidator struct {
+	TagName string
+}
+
+// Validate validates the value
+func (v *RuleValidator) Validate(ctx *Context, value interface{}) error {
+	rules := ctx.StructField.Tag.Get(v.TagName)
+
+	if rules == "" {
+		return nil
+	}
+
+	source := elem(reflect.ValueOf(value))
+
+	if getter, ok := optionalValueOf(source); ok {
+		item, _, valid := getter.optional()
+
+		if !valid {
+			item = reflect.Value{}
+		}
+
+		source = elem(item)
+	}
+
+	for _, rule := range ParseRules(rules) {
+		name, arg := rule.Name, rule.Arg
+
+		if name == RuleRequired {
+			if !source.IsValid() {
+				return v.notSatisfied(ctx, rule.String())
+			}
+
+			continue
+		}
+
+		// the rules are applied only to the present values
+		if !source.IsValid() {
+			continue
+		}
+
+		ok, err := v.check(name, arg, source)
+		if err != nil {
+			return v.errorf("field: '%v' rule: [%v] not supported: %v", ctx.name(), rule, err)
+		}
+
+		if !ok {
+			return v.notSatisfied(ctx, rule.String())
+		}
+	}
+
+	return nil
+}
+
+// Rule is a validation rule of the field's tag
+type Rule struct {
+	Name string
+	Arg  string
+}
+
+// String returns the rule as it is written in the tag
+func (r Rule) String() string {
+	if r.Arg == "" {
+		return r.Name
+	}
+
+	return r.Name + "=" + r.Arg
+}
+
+// ParseRules returns the rules of the tag. The pattern rule takes the rest of
+// the tag so its expression can contain commas (e.g. pattern=^[A-Z]{2,3}$).
+func ParseRules(tag string) []Rule {
+	var (
+		rules = []Rule{}
+		items = strings.Split(tag, ",")
+	)
+
+	for index, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+
+		rule := Rule{
+			Name: strings.ToLower(parts[0]),
+		}
+
+		if len(parts) > 1 {
+			rule.Arg = parts[1]
+		}
+
+		if rule.Name == RulePattern {
+			rule.Arg = strings.Join(append([]string{rule.Arg}, items[index+1:]...), ",")
+			rules = append(rules, rule)
+			break
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func (v *RuleValidator) check(name, arg string, value reflect.Value) (bool, error) {
+	switch name {
+	case RuleMin, RuleMax:
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return false, err
+		}
+
+		number, err := v.number(value)
+		if err != nil {
+			return false, err
+		}
+
+		if name == RuleMin {
+			return number >= limit, nil
+		}
+
+		return number <= limit, nil
+	case RuleMinLength, RuleMaxLength:
+		limit, err := strconv.Atoi(arg)
+		if err != nil {
+			return false, err
+		}
+
+		if kind(value) != reflect.String {
+			return false, fmt.Errorf("%v is not a string", kind(value))
+		}
+
+		count := utf8.RuneCountInString(value.String())
+
+		if name == RuleMinLength {
+			return count >= limit, nil
+		}
+
+		return count <= limit, nil
+	case RuleMinItems, RuleMaxItems:
+		limit, err := strconv.Atoi(arg)
+		if err != nil {
+			return false, err
+		}
+
+		switch kind(value) {
+		case reflect.Array, reflect.Slice, reflect.Map:
+		default:
+			return false, fmt.Errorf("%v is not a collection", kind(value))
+		}
+
+		if name == RuleMinItems {
+			return value.Len() >= limit, nil
+		}
+
+		return value.Len() <= limit, nil
+	case RuleUniqueItems:
+		switch kind(value) {
+		case reflect.Array, reflect.Slice:
+		default:
+			return false, fmt.Errorf("%v is not an array", kind(value))
+		}
+
+		for index := 0; index < value.Len(); index++ {
+			for next := index + 1; next < value.Len(); next++ {
+				if reflect.DeepEqual(value.Index(index).Interface(), value.Index(next).Interface()) {
+					return false, nil
+				}
+			}
+		}
+
+		return true, nil
+	case RulePattern:
+		if kind(value) != reflect.String {
+			return false, fmt.Errorf("%v is not a string", kind(value))
+		}
+
+		pattern, err := v.pattern(arg)
+		if err != nil {
+			return false, err
+		}
+
+		return pattern.MatchString(value.String()), nil
+	case RuleEnum:
+		items := []reflect.Value{value}
+
+		switch kind(value) {
+		case reflect.Array, reflect.Slice:
+			items = items[:0]
+
+			for index := 0; index < value.Len(); index++ {
+				items = append(items, elem(value.Index(index)))
+			}
+		}
+
+		for _, item := range items {
+			if !v.contains(strings.Split(arg, "|"), item) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown rule")
+	}
+}
+
+func (v *RuleValidator) number(value reflect.Value) (float64, error) {
+	switch kind(value) {
+	case reflect.Int:
+		return float64(value.Int()), nil
+	case reflect.Uint:
+		return float64(value.Uint()), nil
+	case reflect.Float32:
+		return value.Float(), nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", kind(value))
+	}
+}
+
+func (v *RuleValidator) contains(values []string, value reflect.Value) bool {
+	text := fmt.Sprintf("%v", value.Interface())
+
+	for _, item := range values {
+		if item == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *RuleValidator) pattern(expr string) (*regexp.Regexp, error) {
+	if pattern, ok := patterns.Load(expr); ok {
+		return pattern.(*regexp.Regexp), nil
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns.Store(expr, pattern)
+	return pattern, nil
+}
+
+func (v *RuleValidator) notSatisfied(ctx *Context, rule string) error {
+	return v.errorf("field: '%v' rule: [%v] not satisfied", ctx.name(), rule)
+}
+
+func (v *RuleValidator) errorf(msg string, values ...interface{}) error {
+	msg = fmt.Sprintf(msg, values...)
+	return fmt.Errorf("validate: %s", msg)
+}
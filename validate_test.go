@@ -0,0 +1,164 @@
+package inflate_test
+
+import (
+	"net/url"
+	"reflect"
+
+	"github.com/phogolabs/inflate"
+	"github.com/phogolabs/inflate/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RuleValidator", func() {
+	type Filter struct {
+		Limit  int      `query:"limit" validate:"required,min=1,max=100"`
+		Sort   string   `query:"sort" validate:"enum=asc|desc"`
+		Name   string   `query:"name" validate:"minlength=2,maxlength=5,pattern=^[a-z]+$"`
+		Tags   []string `query:"tags" validate:"minitems=1,maxitems=2,uniqueitems,enum=a|b|c"`
+		Status string   `query:"status" validate:"unknown"`
+		Code   string   `query:"code" validate:"required,pattern=^[A-Z]{2,3}$"`
+	}
+
+	var (
+		validator *inflate.RuleValidator
+		ctx       *inflate.Context
+	)
+
+	context := func(name string) *inflate.Context {
+		field, _ := reflect.TypeOf(Filter{}).FieldByName(name)
+
+		return &inflate.Context{
+			Field:       name,
+			Type:        field.Type,
+			StructField: field,
+			Tag:         inflate.ParseTag("query", field.Tag.Get("query")),
+		}
+	}
+
+	BeforeEach(func() {
+		validator = &inflate.RuleValidator{TagName: "validate"}
+		ctx = context("Limit")
+	})
+
+	It("validates the value successfully", func() {
+		Expect(validator.Validate(ctx, 10)).To(Succeed())
+		Expect(validator.Validate(context("Sort"), "asc")).To(Succeed())
+		Expect(validator.Validate(context("Name"), "jack")).To(Succeed())
+		Expect(validator.Validate(context("Tags"), []string{"a", "b"})).To(Succeed())
+		Expect(validator.Validate(context("Code"), "AB")).To(Succeed())
+	})
+
+	Context("when the required value is not present", func() {
+		It("returns an error", func() {
+			Expect(validator.Validate(ctx, nil)).To(MatchError("validate: field: 'limit' rule: [required] not satisfied"))
+		})
+	})
+
+	Context("when the field is nested", func() {
+		It("returns an error with the path of the field", func() {
+			ctx.TagPath = []string{"filter", "limit"}
+			Expect(validator.Validate(ctx, nil)).To(MatchError("validate: field: 'filter.limit' rule: [required] not satisfied"))
+		})
+	})
+
+	Context("when the value is not present", func() {
+		It("does not apply the rules", func() {
+			Expect(validator.Validate(context("Sort"), nil)).To(Succeed())
+		})
+	})
+
+	Context("when the optional value is not valid", func() {
+		It("returns an error", func() {
+			value := inflate.Optional[int]{}
+			value.Clear()
+
+			Expect(validator.Validate(ctx, value)).To(MatchError("validate: field: 'limit' rule: [required] not satisfied"))
+		})
+	})
+
+	DescribeTable("when the value does not satisfy the rule",
+		func(name string, value interface{}, msg string) {
+			Expect(validator.Validate(context(name), value)).To(MatchError(msg))
+		},
+		Entry("min", "Limit", 0, "validate: field: 'limit' rule: [min=1] not satisfied"),
+		Entry("max", "Limit", 101, "validate: field: 'limit' rule: [max=100] not satisfied"),
+		Entry("enum", "Sort", "up", "validate: field: 'sort' rule: [enum=asc|desc] not satisfied"),
+		Entry("minlength", "Name", "j", "validate: field: 'name' rule: [minlength=2] not satisfied"),
+		Entry("maxlength", "Name", "jackson", "validate: field: 'name' rule: [maxlength=5] not satisfied"),
+		Entry("pattern", "Name", "Jack", "validate: field: 'name' rule: [pattern=^[a-z]+$] not satisfied"),
+		Entry("pattern with quantifier", "Code", "ABCD", "validate: field: 'code' rule: [pattern=^[A-Z]{2,3}$] not satisfied"),
+		Entry("minitems", "Tags", []string{}, "validate: field: 'tags' rule: [minitems=1] not satisfied"),
+		Entry("maxitems", "Tags", []string{"a", "b", "c"}, "validate: field: 'tags' rule: [maxitems=2] not satisfied"),
+		Entry("uniqueitems", "Tags", []string{"a", "a"}, "validate: field: 'tags' rule: [uniqueitems] not satisfied"),
+		Entry("enum items", "Tags", []string{"a", "d"}, "validate: field: 'tags' rule: [enum=a|b|c] not satisfied"),
+	)
+
+	Context("when the rule is unknown", func() {
+		It("returns an error", func() {
+			Expect(validator.Validate(context("Status"), "active")).To(MatchError("validate: field: 'status' rule: [unknown] not supported: unknown rule"))
+		})
+	})
+
+	Context("when the rule does not support the type", func() {
+		It("returns an error", func() {
+			Expect(validator.Validate(ctx, "ten")).To(MatchError("validate: field: 'limit' rule: [min=1] not supported: string is not a number"))
+		})
+	})
+
+	Describe("Decoder", func() {
+		type Page struct {
+			Limit int    `query:"limit" validate:"required,max=100"`
+			Sort  string `query:"sort" validate:"enum=asc|desc"`
+		}
+
+		It("validates the converted values", func() {
+			query, err := url.ParseQuery("limit=500")
+			Expect(err).To(BeNil())
+
+			page := &Page{}
+			Expect(inflate.NewQueryDecoder(query).Decode(page)).To(MatchError("validate: field: 'limit' rule: [max=100] not satisfied"))
+		})
+
+		Context("when the required value is not present", func() {
+			It("returns an error", func() {
+				page := &Page{}
+				Expect(inflate.NewQueryDecoder(url.Values{}).Decode(page)).To(MatchError("validate: field: 'limit' rule: [required] not satisfied"))
+			})
+		})
+
+		Context("when the values are valid", func() {
+			It("decodes the values successfully", func() {
+				query, err := url.ParseQuery("limit=50&sort=asc")
+				Expect(err).To(BeNil())
+
+				page := &Page{}
+				Expect(inflate.NewQueryDecoder(query).Decode(page)).To(Succeed())
+				Expect(page.Limit).To(Equal(50))
+				Expect(page.Sort).To(Equal("asc"))
+			})
+		})
+
+		Context("when the validator is fake", func() {
+			It("passes the converted value", func() {
+				query, err := url.ParseQuery("limit=50")
+				Expect(err).To(BeNil())
+
+				validator := &fake.ValueValidator{}
+				decoder := inflate.NewQueryDecoder(query)
+				decoder.Validator = validator
+
+				Expect(decoder.Decode(&Page{})).To(Succeed())
+				Expect(validator.ValidateCallCount()).To(Equal(2))
+
+				ctx, value := validator.ValidateArgsForCall(0)
+				Expect(ctx.Field).To(Equal("Limit"))
+				Expect(value).To(Equal(50))
+
+				_, value = validator.ValidateArgsForCall(1)
+				Expect(value).To(BeNil())
+			})
+		})
+	})
+})